@@ -1,17 +1,24 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
+	"time"
 
 	"question-answer/internal/config"
 	"question-answer/internal/domain/qa"
+	auth "question-answer/internal/domain/users"
 	"question-answer/internal/infrastructure/http/handlers"
 	mw "question-answer/internal/infrastructure/http/middleware"
+	"question-answer/internal/infrastructure/mail"
+	"question-answer/internal/infrastructure/oidc"
 	"question-answer/internal/infrastructure/storage/postgres"
+	"question-answer/internal/infrastructure/token"
 
 	"question-answer/pkg/sl_logger/sl"
 	"question-answer/pkg/sl_logger/slogpretty"
@@ -41,6 +48,7 @@ func main() {
 			cfg.DataBase.Sslmode,
 		),
 		MigrationsPath: "internal/infrastructure/storage/postgres/migrations",
+		SearchLanguage: cfg.QA.Search.Language,
 	}
 
 	log.Info("CHECKING DB Conn,", slog.String("Trying to connect with DSN", pgConfig.DSN))
@@ -51,7 +59,61 @@ func main() {
 
 	_ = storage
 
-	service := qa.NewService(storage)
+	service := qa.NewService(storage, qa.Config{
+		DefaultPageSize: cfg.QA.Pagination.DefaultLimit,
+		MaxPageSize:     cfg.QA.Pagination.MaxLimit,
+	})
+	tokens, err := token.NewJWTManager(token.Config{
+		Algorithm:      cfg.Auth.JWT.Algorithm,
+		Secret:         cfg.Auth.JWT.Secret,
+		PrivateKeyPath: cfg.Auth.JWT.PrivateKeyPath,
+		PublicKeyPath:  cfg.Auth.JWT.PublicKeyPath,
+		Issuer:         cfg.Auth.JWT.Issuer,
+		AccessTTL:      cfg.Auth.JWT.AccessTTL,
+	})
+	if err != nil {
+		log.Error("failed to init token manager", sl.Err(err))
+		os.Exit(1)
+	}
+
+	mailer, err := setupMailer(cfg.Mail)
+	if err != nil {
+		log.Error("failed to init mailer", sl.Err(err))
+		os.Exit(1)
+	}
+
+	authService := auth.NewService(storage, tokens, mailer, auth.Config{
+		SessionTTL: cfg.Auth.SessionTTL,
+		ResetTTL:   cfg.Auth.PasswordReset.TTL,
+		ResetURL:   cfg.Auth.PasswordReset.URL,
+
+		TOTPIssuer:              cfg.Auth.TwoFactor.Issuer,
+		Require2FAForPrivileged: cfg.Auth.TwoFactor.RequiredForPrivileged,
+
+		Lockout: auth.LockoutPolicy{
+			Window:           cfg.Auth.Lockout.Window,
+			FreeAttempts:     cfg.Auth.Lockout.FreeAttempts,
+			BaseDelay:        cfg.Auth.Lockout.BaseDelay,
+			MaxDelay:         cfg.Auth.Lockout.MaxDelay,
+			MaxAttemptsPerIP: cfg.Auth.Lockout.MaxAttemptsPerIP,
+		},
+	})
+
+	var (
+		oidcProvider *oidc.Provider
+		oidcStates   *oidc.StateCodec
+	)
+	if cfg.Auth.OIDC.Enabled {
+		oidcProvider, oidcStates, err = setupOIDC(log, cfg.Auth.OIDC)
+		if err != nil {
+			log.Error("failed to init oidc provider", sl.Err(err))
+			os.Exit(1)
+		}
+	}
+
+	if storage != nil {
+		go runTrashPurge(log, service, cfg.QA.Trash)
+	}
 
 	r := chi.NewRouter()
 	r.Use(middleware.RequestID)
@@ -59,39 +121,224 @@ func main() {
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.URLFormat)
 	r.Use(mw.NewMWLogger(log))
+	r.Use(mw.NewAuth(log, authService))
+
+	r.Route("/auth", func(r chi.Router) {
+		r.Post("/register", handlers.NewRegisterHandler(log, authService).ServeHTTP)
+		r.Post("/login", handlers.NewLoginHandler(log, authService).ServeHTTP)
+		r.Post("/refresh", handlers.NewRefreshHandler(log, authService).ServeHTTP)
+		r.With(mw.RequireSession).Post("/logout", handlers.NewLogoutHandler(log, authService, false).ServeHTTP)
+		r.With(mw.RequireSession).Post("/logout/all", handlers.NewLogoutHandler(log, authService, true).ServeHTTP)
+		r.Post("/password/forgot", handlers.NewForgotPasswordHandler(log, authService).ServeHTTP)
+		r.Post("/password/reset", handlers.NewResetPasswordHandler(log, authService).ServeHTTP)
+
+		r.Route("/2fa", func(r chi.Router) {
+			r.Use(mw.RequireSession)
+			r.Post("/enroll", handlers.NewEnrollTOTPHandler(log, authService).ServeHTTP)
+			r.Post("/confirm", handlers.NewConfirmTOTPHandler(log, authService).ServeHTTP)
+			r.Post("/disable", handlers.NewDisableTOTPHandler(log, authService).ServeHTTP)
+		})
+
+		if oidcProvider != nil {
+			r.Get("/oidc/login", handlers.NewOIDCLoginHandler(log, oidcProvider, oidcStates).ServeHTTP)
+			r.Get("/oidc/callback", handlers.NewOIDCCallbackHandler(log, authService, oidcProvider, oidcStates).ServeHTTP)
+			r.Post("/oidc/2fa", handlers.NewOIDCSecondFactorHandler(log, authService, oidcStates).ServeHTTP)
+		}
+
+		r.Route("/tokens", func(r chi.Router) {
+			r.Use(mw.RequireSession)
+			r.Get("/", handlers.NewListAPITokensHandler(log, authService).ServeHTTP)
+			r.Post("/", handlers.NewCreateAPITokenHandler(log, authService).ServeHTTP)
+			r.Delete("/{tokenID}", func(w http.ResponseWriter, r *http.Request) {
+				id := chi.URLParam(r, "tokenID")
+				handlers.NewRevokeAPITokenHandler(log, authService, id).ServeHTTP(w, r)
+			})
+		})
+	})
 
 	r.Route("/questions", func(r chi.Router) {
-		r.Get("/", handlers.NewGetQuestionHandler(log, service).ServeHTTP)
-		r.Post("/", handlers.NewAddQuestionHandler(log, service).ServeHTTP)
+		r.With(mw.RequireScope(auth.ScopeQuestionsRead)).Get("/", handlers.NewGetQuestionHandler(log, service).ServeHTTP)
+		r.With(mw.RequireAuth, mw.RequireScope(auth.ScopeQuestionsWrite)).Post("/", handlers.NewAddQuestionHandler(log, service).ServeHTTP)
+		r.With(mw.RequireScope(auth.ScopeQuestionsRead)).Get("/similar", handlers.NewSimilarQuestionsHandler(log, service).ServeHTTP)
 
 		r.Route("/{questionID}", func(r chi.Router) {
-			r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			r.With(mw.RequireScope(auth.ScopeQuestionsRead)).Get("/", func(w http.ResponseWriter, r *http.Request) {
 				id := chi.URLParam(r, "questionID")
 				handlers.NewGetAllQuestionHandler(log, service, id).ServeHTTP(w, r)
 			})
-			r.Delete("/", func(w http.ResponseWriter, r *http.Request) {
+			r.With(mw.RequireAuth, mw.RequireScope(auth.ScopeQuestionsWrite)).Delete("/", func(w http.ResponseWriter, r *http.Request) {
 				id := chi.URLParam(r, "questionID")
 				handlers.NewDeleteQuestionHandler(log, service, id).ServeHTTP(w, r)
 			})
+			r.With(mw.RequireAuth, mw.RequireScope(auth.ScopeQuestionsWrite)).Patch("/", func(w http.ResponseWriter, r *http.Request) {
+				id := chi.URLParam(r, "questionID")
+				handlers.NewEditQuestionHandler(log, service, id).ServeHTTP(w, r)
+			})
+			r.With(mw.RequireAuth, mw.RequireScope(auth.ScopeQuestionsWrite)).Post("/restore", func(w http.ResponseWriter, r *http.Request) {
+				id := chi.URLParam(r, "questionID")
+				handlers.NewRestoreQuestionHandler(log, service, id).ServeHTTP(w, r)
+			})
+			r.Route("/revisions", func(r chi.Router) {
+				r.With(mw.RequireScope(auth.ScopeQuestionsRead)).Get("/", func(w http.ResponseWriter, r *http.Request) {
+					id := chi.URLParam(r, "questionID")
+					handlers.NewListRevisionsHandler(log, service, false, id).ServeHTTP(w, r)
+				})
+				r.With(mw.RequireAuth, mw.RequireScope(auth.ScopeQuestionsWrite)).Post("/{revisionID}/rollback", func(w http.ResponseWriter, r *http.Request) {
+					id := chi.URLParam(r, "questionID")
+					revisionID := chi.URLParam(r, "revisionID")
+					handlers.NewRollbackQuestionHandler(log, service, id, revisionID).ServeHTTP(w, r)
+				})
+			})
 			r.Route("/answers", func(r chi.Router) {
-				r.Post("/", func(w http.ResponseWriter, r *http.Request) {
+				r.With(mw.RequireAuth, mw.RequireScope(auth.ScopeAnswersWrite)).Post("/", func(w http.ResponseWriter, r *http.Request) {
 					questionID := chi.URLParam(r, "questionID")
 					handlers.NewAddAnswerHandler(log, service, questionID).ServeHTTP(w, r)
 				})
 			})
+			r.With(mw.RequireAuth, mw.RequireScope(auth.ScopeQuestionsWrite)).Post("/accept/{answerID}", func(w http.ResponseWriter, r *http.Request) {
+				questionID := chi.URLParam(r, "questionID")
+				answerID := chi.URLParam(r, "answerID")
+				handlers.NewAcceptAnswerHandler(log, service, questionID, answerID).ServeHTTP(w, r)
+			})
+			r.Route("/duplicate", func(r chi.Router) {
+				r.Use(mw.RequireAuth, mw.RequirePermission(auth.PermModerateContent))
+				r.Post("/", func(w http.ResponseWriter, r *http.Request) {
+					questionID := chi.URLParam(r, "questionID")
+					handlers.NewMarkDuplicateHandler(log, service, questionID).ServeHTTP(w, r)
+				})
+				r.Delete("/", func(w http.ResponseWriter, r *http.Request) {
+					questionID := chi.URLParam(r, "questionID")
+					handlers.NewReopenHandler(log, service, questionID).ServeHTTP(w, r)
+				})
+			})
+			r.Route("/vote", func(r chi.Router) {
+				r.Use(mw.RequireAuth, mw.RequireScope(auth.ScopeQuestionsWrite))
+				r.Post("/", func(w http.ResponseWriter, r *http.Request) {
+					questionID := chi.URLParam(r, "questionID")
+					handlers.NewVoteHandler(log, service, false, questionID).ServeHTTP(w, r)
+				})
+				r.Delete("/", func(w http.ResponseWriter, r *http.Request) {
+					questionID := chi.URLParam(r, "questionID")
+					handlers.NewUnvoteHandler(log, service, false, questionID).ServeHTTP(w, r)
+				})
+			})
+			r.Route("/comments", func(r chi.Router) {
+				r.With(mw.RequireScope(auth.ScopeCommentsRead)).Get("/", func(w http.ResponseWriter, r *http.Request) {
+					questionID := chi.URLParam(r, "questionID")
+					handlers.NewListCommentsHandler(log, service, false, questionID).ServeHTTP(w, r)
+				})
+				r.With(mw.RequireAuth, mw.RequireScope(auth.ScopeCommentsWrite)).Post("/", func(w http.ResponseWriter, r *http.Request) {
+					questionID := chi.URLParam(r, "questionID")
+					handlers.NewAddCommentHandler(log, service, false, questionID).ServeHTTP(w, r)
+				})
+			})
+		})
+	})
+	r.With(mw.RequireAuth, mw.RequireScope(auth.ScopeCommentsWrite)).Delete("/comments/{commentID}", func(w http.ResponseWriter, r *http.Request) {
+		commentID := chi.URLParam(r, "commentID")
+		handlers.NewDeleteCommentHandler(log, service, commentID).ServeHTTP(w, r)
+	})
+	r.With(mw.RequireScope(auth.ScopeQuestionsRead), mw.RequireScope(auth.ScopeAnswersRead)).
+		Get("/search", handlers.NewSearchHandler(log, service).ServeHTTP)
+	r.Route("/tags", func(r chi.Router) {
+		r.With(mw.RequireScope(auth.ScopeQuestionsRead)).Get("/", handlers.NewListTagsHandler(log, service).ServeHTTP)
+		r.With(mw.RequireAuth, mw.RequirePermission(auth.PermModerateContent)).Post("/{tag}/synonyms", func(w http.ResponseWriter, r *http.Request) {
+			tag := chi.URLParam(r, "tag")
+			handlers.NewAddTagSynonymHandler(log, service, tag).ServeHTTP(w, r)
 		})
 	})
 	r.Route("/answers", func(r chi.Router) {
 		r.Route("/{answerID}", func(r chi.Router) {
-			r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			r.With(mw.RequireScope(auth.ScopeAnswersRead)).Get("/", func(w http.ResponseWriter, r *http.Request) {
 				questionID := chi.URLParam(r, "answerID")
 				handlers.NewGetAnswerHandler(log, service, questionID).ServeHTTP(w, r)
 			})
-			r.Delete("/", func(w http.ResponseWriter, r *http.Request) {
+			r.With(mw.RequireAuth, mw.RequireScope(auth.ScopeAnswersWrite)).Delete("/", func(w http.ResponseWriter, r *http.Request) {
 				questionID := chi.URLParam(r, "answerID")
 				handlers.NewDeleteAnswerHandler(log, service, questionID).ServeHTTP(w, r)
 			})
+			r.With(mw.RequireAuth, mw.RequireScope(auth.ScopeAnswersWrite)).Patch("/", func(w http.ResponseWriter, r *http.Request) {
+				answerID := chi.URLParam(r, "answerID")
+				handlers.NewEditAnswerHandler(log, service, answerID).ServeHTTP(w, r)
+			})
+			r.With(mw.RequireAuth, mw.RequireScope(auth.ScopeAnswersWrite)).Post("/restore", func(w http.ResponseWriter, r *http.Request) {
+				answerID := chi.URLParam(r, "answerID")
+				handlers.NewRestoreAnswerHandler(log, service, answerID).ServeHTTP(w, r)
+			})
+			r.Route("/revisions", func(r chi.Router) {
+				r.With(mw.RequireScope(auth.ScopeAnswersRead)).Get("/", func(w http.ResponseWriter, r *http.Request) {
+					answerID := chi.URLParam(r, "answerID")
+					handlers.NewListRevisionsHandler(log, service, true, answerID).ServeHTTP(w, r)
+				})
+				r.With(mw.RequireAuth, mw.RequireScope(auth.ScopeAnswersWrite)).Post("/{revisionID}/rollback", func(w http.ResponseWriter, r *http.Request) {
+					answerID := chi.URLParam(r, "answerID")
+					revisionID := chi.URLParam(r, "revisionID")
+					handlers.NewRollbackAnswerHandler(log, service, answerID, revisionID).ServeHTTP(w, r)
+				})
+			})
+			r.Route("/comments", func(r chi.Router) {
+				r.With(mw.RequireScope(auth.ScopeCommentsRead)).Get("/", func(w http.ResponseWriter, r *http.Request) {
+					answerID := chi.URLParam(r, "answerID")
+					handlers.NewListCommentsHandler(log, service, true, answerID).ServeHTTP(w, r)
+				})
+				r.With(mw.RequireAuth, mw.RequireScope(auth.ScopeCommentsWrite)).Post("/", func(w http.ResponseWriter, r *http.Request) {
+					answerID := chi.URLParam(r, "answerID")
+					handlers.NewAddCommentHandler(log, service, true, answerID).ServeHTTP(w, r)
+				})
+			})
+			r.Route("/vote", func(r chi.Router) {
+				r.Use(mw.RequireAuth, mw.RequireScope(auth.ScopeAnswersWrite))
+				r.Post("/", func(w http.ResponseWriter, r *http.Request) {
+					answerID := chi.URLParam(r, "answerID")
+					handlers.NewVoteHandler(log, service, true, answerID).ServeHTTP(w, r)
+				})
+				r.Delete("/", func(w http.ResponseWriter, r *http.Request) {
+					answerID := chi.URLParam(r, "answerID")
+					handlers.NewUnvoteHandler(log, service, true, answerID).ServeHTTP(w, r)
+				})
+			})
+		})
+	})
+
+	r.Route("/users/{userID}", func(r chi.Router) {
+		// The profile's reputation is earned on questions and answers.
+		r.With(mw.RequireScope(auth.ScopeQuestionsRead), mw.RequireScope(auth.ScopeAnswersRead)).Get("/", func(w http.ResponseWriter, r *http.Request) {
+			userID := chi.URLParam(r, "userID")
+			handlers.NewGetUserHandler(log, authService, service, userID).ServeHTTP(w, r)
+		})
+		r.With(mw.RequireScope(auth.ScopeQuestionsRead)).Get("/questions", func(w http.ResponseWriter, r *http.Request) {
+			userID := chi.URLParam(r, "userID")
+			handlers.NewGetUserQuestionsHandler(log, authService, service, userID).ServeHTTP(w, r)
+		})
+		r.With(mw.RequireScope(auth.ScopeAnswersRead)).Get("/answers", func(w http.ResponseWriter, r *http.Request) {
+			userID := chi.URLParam(r, "userID")
+			handlers.NewGetUserAnswersHandler(log, authService, service, userID).ServeHTTP(w, r)
+		})
+	})
+
+	r.Route("/admin", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(mw.RequirePermission(auth.PermManageRoles))
+
+			r.Route("/users/{userID}/roles", func(r chi.Router) {
+				r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+					userID := chi.URLParam(r, "userID")
+					handlers.NewGetUserRolesHandler(log, authService, userID).ServeHTTP(w, r)
+				})
+				r.Post("/", func(w http.ResponseWriter, r *http.Request) {
+					userID := chi.URLParam(r, "userID")
+					handlers.NewGrantRoleHandler(log, authService, userID).ServeHTTP(w, r)
+				})
+				r.Delete("/{role}", func(w http.ResponseWriter, r *http.Request) {
+					userID := chi.URLParam(r, "userID")
+					role := chi.URLParam(r, "role")
+					handlers.NewRevokeRoleHandler(log, authService, userID, role).ServeHTTP(w, r)
+				})
+			})
 		})
+
+		r.With(mw.RequirePermission(auth.PermViewAuditLog)).Get("/auth-events", handlers.NewListAuthEventsHandler(log, authService).ServeHTTP)
+		r.With(mw.RequirePermission(auth.PermModerateContent)).Get("/trash", handlers.NewListTrashHandler(log, service).ServeHTTP)
 	})
 
 	srv := &http.Server{
@@ -137,6 +384,77 @@ func setupLogger(env string) *slog.Logger {
 	return log
 }
 
+func setupMailer(cfg config.Mail) (auth.Mailer, error) {
+	switch cfg.Driver {
+	case "smtp":
+		return mail.NewSMTPMailer(mail.SMTPConfig{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password,
+			From:     cfg.From,
+		}), nil
+	case "outbox", "":
+		return mail.NewOutboxMailer(cfg.OutboxDir, cfg.From)
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", cfg.Driver)
+	}
+}
+
+func setupOIDC(log *slog.Logger, cfg config.OIDC) (*oidc.Provider, *oidc.StateCodec, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	provider, err := oidc.New(ctx, oidc.Config{
+		IssuerURL:    cfg.IssuerURL,
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		RedirectURL:  cfg.RedirectURL,
+		Scopes:       cfg.Scopes,
+	}, &http.Client{Timeout: 10 * time.Second})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	secret := []byte(cfg.StateSecret)
+	if len(secret) == 0 {
+		// Logins in flight during a restart will have to start over.
+		log.Warn("oidc state secret is not set, using a random one")
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return provider, oidc.NewStateCodec(secret, cfg.StateTTL), nil
+}
+
+// runTrashPurge permanently removes content that has stayed in the trash
+// longer than the retention period, checking every cfg.PurgeInterval.
+func runTrashPurge(log *slog.Logger, svc qa.Service, cfg config.Trash) {
+	log = log.With(slog.String("op", "main.runTrashPurge"))
+
+	// time.NewTicker panics on a non-positive period.
+	if cfg.PurgeInterval <= 0 {
+		log.Warn("trash purge is disabled", slog.Duration("purge_interval", cfg.PurgeInterval))
+		return
+	}
+
+	ticker := time.NewTicker(cfg.PurgeInterval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		purged, err := svc.PurgeTrash(time.Now().Add(-cfg.Retention))
+		if err != nil {
+			log.Error("failed to purge trash", sl.Err(err))
+			continue
+		}
+		if purged > 0 {
+			log.Info("trash purged", slog.Int64("purged", purged))
+		}
+	}
+}
+
 func setupPrettySlog() *slog.Logger {
 	opts := slogpretty.PrettyHandlerOptions{
 		SlogOpts: &slog.HandlerOptions{
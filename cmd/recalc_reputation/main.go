@@ -0,0 +1,45 @@
+// Command recalc_reputation rebuilds every user's reputation total from the
+// reputation ledger. Run it after fixing ledger entries by hand or when the
+// totals are suspected to have drifted.
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"question-answer/internal/config"
+	"question-answer/internal/domain/qa"
+	"question-answer/internal/infrastructure/storage/postgres"
+
+	"question-answer/pkg/sl_logger/sl"
+)
+
+func main() {
+	cfg := config.MustLoad()
+	log := slog.New(slog.NewTextHandler(os.Stdout, nil)).With(slog.String("env", cfg.Env))
+
+	storage, err := postgres.New(postgres.Config{
+		DSN: fmt.Sprintf("host=%s user=%s port=%s password=%s dbname=%s sslmode=%s",
+			cfg.DataBase.Host,
+			cfg.DataBase.User,
+			cfg.DataBase.Port,
+			cfg.DataBase.Password,
+			cfg.DataBase.Dbname,
+			cfg.DataBase.Sslmode,
+		),
+		MigrationsPath: "internal/infrastructure/storage/postgres/migrations",
+	})
+	if err != nil {
+		log.Error("failed to init storage", sl.Err(err))
+		os.Exit(1)
+	}
+
+	updated, err := qa.NewService(storage, qa.Config{}).RecalculateReputation()
+	if err != nil {
+		log.Error("failed to recalculate reputation", sl.Err(err))
+		os.Exit(1)
+	}
+
+	log.Info("reputation recalculated", slog.Int64("updated_users", updated))
+}
@@ -13,6 +13,9 @@ type Config struct{
 	Env string `yaml:"env" env-defaut:"dev"`
 	HTTPServer `yaml:"http_server"`
 	DataBase `yaml:"database"`
+	Auth `yaml:"auth"`
+	Mail `yaml:"mail"`
+	QA `yaml:"qa"`
 }
 
 type HTTPServer struct{
@@ -30,6 +33,85 @@ type DataBase struct{
 	Sslmode string `yaml:"sslmode" env-default:"disable"`
 }
 
+type Auth struct{
+	JWT JWT `yaml:"jwt"`
+	SessionTTL time.Duration `yaml:"session_ttl" env-default:"720h"`
+	PasswordReset PasswordReset `yaml:"password_reset"`
+	TwoFactor TwoFactor `yaml:"two_factor"`
+	Lockout Lockout `yaml:"lockout"`
+	OIDC OIDC `yaml:"oidc"`
+}
+
+type OIDC struct{
+	Enabled bool `yaml:"enabled" env-default:"false"`
+	IssuerURL string `yaml:"issuer_url"`
+	ClientID string `yaml:"client_id"`
+	ClientSecret string `yaml:"client_secret" env:"OIDC_CLIENT_SECRET"`
+	RedirectURL string `yaml:"redirect_url"`
+	Scopes []string `yaml:"scopes" env-default:"openid,profile,email"`
+	StateSecret string `yaml:"state_secret" env:"OIDC_STATE_SECRET"`
+	StateTTL time.Duration `yaml:"state_ttl" env-default:"10m"`
+}
+type Lockout struct{
+	Window time.Duration `yaml:"window" env-default:"15m"`
+	FreeAttempts int `yaml:"free_attempts" env-default:"5"`
+	BaseDelay time.Duration `yaml:"base_delay" env-default:"1s"`
+	MaxDelay time.Duration `yaml:"max_delay" env-default:"15m"`
+	MaxAttemptsPerIP int `yaml:"max_attempts_per_ip" env-default:"100"`
+}
+type TwoFactor struct{
+	Issuer string `yaml:"issuer" env-default:"question-answer"`
+	RequiredForPrivileged bool `yaml:"required_for_privileged" env-default:"false"`
+}
+
+type PasswordReset struct{
+	TTL time.Duration `yaml:"ttl" env-default:"1h"`
+	URL string `yaml:"url"`
+}
+
+type QA struct{
+	Trash Trash `yaml:"trash"`
+	Search Search `yaml:"search"`
+	Pagination Pagination `yaml:"pagination"`
+}
+
+type Pagination struct{
+	DefaultLimit int `yaml:"default_limit" env-default:"20"`
+	MaxLimit int `yaml:"max_limit" env-default:"100"`
+}
+
+type Search struct{
+	Language string `yaml:"language" env-default:"english"`
+}
+
+type Trash struct{
+	Retention time.Duration `yaml:"retention" env-default:"720h"`
+	PurgeInterval time.Duration `yaml:"purge_interval" env-default:"1h"`
+}
+
+type Mail struct{
+	Driver string `yaml:"driver" env-default:"outbox"`
+	From string `yaml:"from" env-default:"noreply@question-answer.local"`
+	OutboxDir string `yaml:"outbox_dir" env-default:"var/outbox"`
+	SMTP SMTP `yaml:"smtp"`
+}
+
+type SMTP struct{
+	Host string `yaml:"host" env:"SMTP_HOST"`
+	Port string `yaml:"port" env:"SMTP_PORT" env-default:"587"`
+	Username string `yaml:"username" env:"SMTP_USERNAME"`
+	Password string `yaml:"password" env:"SMTP_PASSWORD"`
+}
+
+type JWT struct{
+	Algorithm string `yaml:"algorithm" env-default:"HS256"`
+	Secret string `yaml:"secret" env:"JWT_SECRET"`
+	PrivateKeyPath string `yaml:"private_key_path" env:"JWT_PRIVATE_KEY_PATH"`
+	PublicKeyPath string `yaml:"public_key_path" env:"JWT_PUBLIC_KEY_PATH"`
+	Issuer string `yaml:"issuer" env-default:"question-answer"`
+	AccessTTL time.Duration `yaml:"access_ttl" env-default:"15m"`
+}
+
 func MustLoad() *Config  {
 	configPath := os.Getenv("CONFIG_PATH")
 	if configPath == ""{
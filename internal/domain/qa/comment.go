@@ -0,0 +1,39 @@
+package qa
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	MinCommentLength = 2
+	MaxCommentLength = 300
+)
+
+var (
+	ErrCommentNotFound = errors.New("comment not found")
+	ErrCommentTarget   = errors.New("comment must target either a question or an answer")
+	ErrInvalidComment  = fmt.Errorf("comment must be %d to %d characters long", MinCommentLength, MaxCommentLength)
+)
+
+// Comment is a short remark on either a question or an answer; exactly one
+// of QuestionID and AnswerID is set.
+type Comment struct {
+	ID         uint64    `json:"id"`
+	QuestionID uint64    `json:"question_id,omitempty"`
+	AnswerID   uint64    `json:"answer_id,omitempty"`
+	UserID     uint64    `json:"user_id"`
+	Text       string    `json:"text"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func validateComment(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if n := utf8.RuneCountInString(text); n < MinCommentLength || n > MaxCommentLength {
+		return "", ErrInvalidComment
+	}
+	return text, nil
+}
@@ -0,0 +1,21 @@
+package qa
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrQuestionClosed   = errors.New("question is closed")
+	ErrInvalidDuplicate = errors.New("a question cannot duplicate itself")
+)
+
+// Closed reports whether the question accepts no new answers.
+func (q Question) Closed() bool {
+	return q.DuplicateOfID != 0
+}
+
+// closedError explains where answers to a closed question belong.
+func closedError(q Question) error {
+	return fmt.Errorf("%w: duplicate of question %d, answer there instead", ErrQuestionClosed, q.DuplicateOfID)
+}
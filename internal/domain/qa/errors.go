@@ -0,0 +1,11 @@
+package qa
+
+import "errors"
+
+var (
+	ErrAnonymous        = errors.New("authentication required")
+	ErrForbidden        = errors.New("not allowed to modify this resource")
+	ErrQuestionNotFound = errors.New("question not found")
+	ErrAnswerNotFound   = errors.New("answer not found")
+	ErrAnswerMismatch   = errors.New("answer does not belong to this question")
+)
@@ -0,0 +1,87 @@
+package qa
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const MaxFilterTextLength = 200
+
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// QuestionSort names an order of the question list.
+type QuestionSort string
+
+const (
+	SortCreated  QuestionSort = "created"
+	SortAnswers  QuestionSort = "answers"
+	SortActivity QuestionSort = "activity"
+)
+
+// Valid reports whether s is one of the known orders.
+func (s QuestionSort) Valid() bool {
+	switch s {
+	case SortCreated, SortAnswers, SortActivity:
+		return true
+	}
+	return false
+}
+
+// QuestionFilter narrows and orders GetAllQuestions. Zero fields do not
+// filter.
+type QuestionFilter struct {
+	// Answered selects questions with (true) or without (false) an
+	// accepted answer.
+	Answered *bool
+	// HasAnswers selects questions with (true) or without (false) any
+	// answers.
+	HasAnswers *bool
+	// Tags selects questions carrying every listed tag. Synonyms match
+	// their tag.
+	Tags []string
+	// AuthorID selects the questions asked by this user.
+	AuthorID uint64
+	// CreatedFrom and CreatedTo bound the creation time, the former
+	// inclusive and the latter exclusive.
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	// Text selects questions whose title or body contains it, ignoring
+	// case.
+	Text string
+
+	// Sort orders the list, SortCreated when empty. Ties are broken by
+	// ID.
+	Sort QuestionSort
+	// Desc reverses the order, e.g. newest first.
+	Desc bool
+}
+
+// normalize validates the filter and fills in defaults.
+func (f QuestionFilter) normalize() (QuestionFilter, error) {
+	tags, err := NormalizeTags(f.Tags)
+	if err != nil {
+		return f, err
+	}
+	f.Tags = tags
+
+	if f.Sort == "" {
+		f.Sort = SortCreated
+	}
+	if !f.Sort.Valid() {
+		return f, fmt.Errorf("%w: unknown sort %q", ErrInvalidFilter, f.Sort)
+	}
+
+	if f.CreatedFrom != nil && f.CreatedTo != nil && !f.CreatedFrom.Before(*f.CreatedTo) {
+		return f, fmt.Errorf("%w: empty creation time range", ErrInvalidFilter)
+	}
+
+	f.Text = strings.TrimSpace(f.Text)
+	if utf8.RuneCountInString(f.Text) > MaxFilterTextLength {
+		return f, fmt.Errorf("%w: text may be at most %d characters long", ErrInvalidFilter, MaxFilterTextLength)
+	}
+
+	return f, nil
+}
@@ -4,9 +4,34 @@ package qa
 import "time"
 
 type Question struct {
-	ID        uint64    `json:"id"`
-	Text      string    `json:"text" validate:"required,min=3,max=500"`
+	ID     uint64 `json:"id"`
+	UserID uint64 `json:"user_id"`
+	// Title is the plain-text headline.
+	Title string `json:"title" validate:"required,min=3,max=150"`
+	// Body holds the details as Markdown and may be empty.
+	Body      string    `json:"body" validate:"max=30000"`
 	CreatedAt time.Time `json:"created_at"`
+	// AcceptedAnswerID is the answer the asker marked as the solution, or
+	// zero.
+	AcceptedAnswerID uint64 `json:"accepted_answer_id,omitempty"`
+	// DuplicateOfID is the canonical question this one was closed as a
+	// duplicate of, or zero.
+	DuplicateOfID uint64 `json:"duplicate_of_id,omitempty"`
+	// Tags holds normalized tag names, synonyms already resolved.
+	Tags []string `json:"tags,omitempty"`
+	// Score is the sum of all votes on the question.
+	Score int64 `json:"score"`
+	// ViewerVote is the vote of the user the question was loaded for.
+	ViewerVote Vote `json:"my_vote,omitempty"`
+	// AnswerCount and LastActivityAt are only filled in by
+	// GetAllQuestions. Activity is the question being asked or edited and
+	// its answers being posted or edited.
+	AnswerCount    int64     `json:"answer_count"`
+	LastActivityAt time.Time `json:"last_activity_at"`
+	// Comments is only filled in by GetQuestionWithAnswers.
+	Comments []Comment `json:"comments,omitempty"`
+	// DeletedAt is set while the question is in the trash.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 type Answer struct {
@@ -15,4 +40,32 @@ type Answer struct {
 	UserID     uint64    `json:"user_id" validate:"required"`
 	Text       string    `json:"text" validate:"required,min=1,max=1000"`
 	CreatedAt  time.Time `json:"created_at"`
+	// Score is the sum of all votes on the answer.
+	Score int64 `json:"score"`
+	// ViewerVote is the vote of the user the answer was loaded for.
+	ViewerVote Vote `json:"my_vote,omitempty"`
+	// AuthorReputation is the current reputation of the answer's author.
+	AuthorReputation int64 `json:"author_reputation"`
+	// Accepted is set on the answer the asker marked as the solution.
+	Accepted bool `json:"accepted"`
+	// Comments is only filled in by GetQuestionWithAnswers.
+	Comments []Comment `json:"comments,omitempty"`
+	// DeletedAt is set while the answer is in the trash.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// Actor is the user on whose behalf a service operation is performed.
+// Privileged actors may act on resources they do not own.
+type Actor struct {
+	UserID     uint64
+	Privileged bool
+}
+
+// CanModify reports whether the actor may change or remove a resource
+// authored by ownerID.
+func (a Actor) CanModify(ownerID uint64) bool {
+	if a.Privileged {
+		return true
+	}
+	return a.UserID != 0 && a.UserID == ownerID
 }
@@ -0,0 +1,92 @@
+package qa
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+const (
+	DefaultPageSize    = 20
+	DefaultMaxPageSize = 100
+)
+
+var (
+	ErrInvalidCursor   = errors.New("invalid cursor")
+	ErrInvalidPageSize = errors.New("page size must be positive")
+)
+
+// PageRequest selects one page of a listing.
+type PageRequest struct {
+	// Limit is the page size. Zero means the configured default; larger
+	// values than the configured maximum are lowered to it.
+	Limit int
+	// After is the NextCursor of the previous page, empty for the first.
+	After string
+}
+
+// QuestionPage is one page of questions. NextCursor is empty on the last
+// page.
+type QuestionPage struct {
+	Questions  []Question
+	NextCursor string
+}
+
+// Cursor is the decoded form of a page cursor: the listing resumes after
+// the question with ID whose sort key was At or Count. A cursor is only
+// valid for the order it was made in. The zero Cursor starts from the
+// beginning.
+type Cursor struct {
+	ID   uint64       `json:"id"`
+	Sort QuestionSort `json:"sort"`
+	Desc bool         `json:"desc,omitempty"`
+	// At holds the key of time orders, Count that of SortAnswers.
+	At    *time.Time `json:"at,omitempty"`
+	Count int64      `json:"count,omitempty"`
+}
+
+// cursorAfter returns the cursor continuing the list ordered by f after q.
+func cursorAfter(q Question, f QuestionFilter) Cursor {
+	c := Cursor{ID: q.ID, Sort: f.Sort, Desc: f.Desc}
+	switch f.Sort {
+	case SortCreated:
+		c.At = &q.CreatedAt
+	case SortActivity:
+		c.At = &q.LastActivityAt
+	case SortAnswers:
+		c.Count = q.AnswerCount
+	}
+	return c
+}
+
+// Encode returns the opaque form handed to clients.
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor parses a cursor produced by Encode for the order of f. The
+// empty string decodes to the zero Cursor.
+func DecodeCursor(s string, f QuestionFilter) (Cursor, error) {
+	var c Cursor
+	if s == "" {
+		return c, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(b, &c); err != nil || c.ID == 0 {
+		return c, ErrInvalidCursor
+	}
+	if c.Sort != f.Sort || c.Desc != f.Desc {
+		return c, ErrInvalidCursor
+	}
+	if c.Sort != SortAnswers && c.At == nil {
+		return c, ErrInvalidCursor
+	}
+
+	return c, nil
+}
@@ -0,0 +1,84 @@
+package qa
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// pagingStorage serves questions 1..n in ID order.
+type pagingStorage struct {
+	Storage
+	n         uint64
+	lastLimit int
+}
+
+func (s *pagingStorage) GetAllQuestions(_ QuestionFilter, after Cursor, limit int) ([]Question, error) {
+	s.lastLimit = limit
+	var res []Question
+	for id := after.ID + 1; id <= s.n && len(res) < limit; id++ {
+		res = append(res, Question{ID: id})
+	}
+	return res, nil
+}
+
+func TestGetAllQuestionsPages(t *testing.T) {
+	st := &pagingStorage{n: 5}
+	svc := NewService(st, Config{DefaultPageSize: 2, MaxPageSize: 3})
+
+	var ids []uint64
+	page := PageRequest{}
+	for {
+		res, err := svc.GetAllQuestions(QuestionFilter{}, page)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(res.Questions), 2)
+		for _, q := range res.Questions {
+			ids = append(ids, q.ID)
+		}
+		if res.NextCursor == "" {
+			break
+		}
+		page.After = res.NextCursor
+	}
+	require.Equal(t, []uint64{1, 2, 3, 4, 5}, ids)
+
+	res, err := svc.GetAllQuestions(QuestionFilter{}, PageRequest{Limit: 50})
+	require.NoError(t, err)
+	require.Len(t, res.Questions, 3)
+	require.Equal(t, 4, st.lastLimit)
+
+	_, err = svc.GetAllQuestions(QuestionFilter{}, PageRequest{After: "not a cursor"})
+	require.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	byAnswers := QuestionFilter{Sort: SortAnswers, Desc: true}
+	cursor := cursorAfter(Question{ID: 42, AnswerCount: 3}, byAnswers)
+
+	c, err := DecodeCursor(cursor.Encode(), byAnswers)
+	require.NoError(t, err)
+	require.Equal(t, Cursor{ID: 42, Sort: SortAnswers, Desc: true, Count: 3}, c)
+
+	// A cursor does not carry over to another order.
+	_, err = DecodeCursor(cursor.Encode(), QuestionFilter{Sort: SortAnswers})
+	require.ErrorIs(t, err, ErrInvalidCursor)
+
+	c, err = DecodeCursor("", byAnswers)
+	require.NoError(t, err)
+	require.Zero(t, c)
+}
+
+func TestQuestionFilterNormalize(t *testing.T) {
+	f, err := QuestionFilter{Text: "  pgx  "}.normalize()
+	require.NoError(t, err)
+	require.Equal(t, SortCreated, f.Sort)
+	require.Equal(t, "pgx", f.Text)
+
+	_, err = QuestionFilter{Sort: "id; DROP TABLE questions"}.normalize()
+	require.ErrorIs(t, err, ErrInvalidFilter)
+
+	now := time.Now()
+	_, err = QuestionFilter{CreatedFrom: &now, CreatedTo: &now}.normalize()
+	require.ErrorIs(t, err, ErrInvalidFilter)
+}
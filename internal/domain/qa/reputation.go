@@ -0,0 +1,58 @@
+package qa
+
+import "time"
+
+// ReputationEvent names what earned or cost a user reputation.
+type ReputationEvent string
+
+const (
+	EventAnswerUpvoted     ReputationEvent = "answer_upvoted"
+	EventAnswerAccepted    ReputationEvent = "answer_accepted"
+	EventQuestionDownvoted ReputationEvent = "question_downvoted"
+)
+
+// Points returns the reputation the event earns the author of the post.
+func (e ReputationEvent) Points() int64 {
+	switch e {
+	case EventAnswerUpvoted:
+		return 10
+	case EventAnswerAccepted:
+		return 15
+	case EventQuestionDownvoted:
+		return -2
+	}
+	return 0
+}
+
+// ReputationEntry is a row of the append-only reputation ledger. Entries are
+// never changed: undoing a vote or moving the accepted mark appends an entry
+// of the same event with the points negated.
+type ReputationEntry struct {
+	ID         uint64
+	UserID     uint64
+	Event      ReputationEvent
+	Points     int64
+	QuestionID uint64
+	AnswerID   uint64
+	// ActorID is the user whose action caused the entry.
+	ActorID   uint64
+	CreatedAt time.Time
+}
+
+// VoteReputation returns the points the author of a post gains when a vote
+// on it changes from prev to next; either may be zero for no vote. Only
+// upvotes on answers and downvotes on questions count.
+func VoteReputation(onAnswer bool, prev, next Vote) (ReputationEvent, int64) {
+	event, counted := EventQuestionDownvoted, VoteDown
+	if onAnswer {
+		event, counted = EventAnswerUpvoted, VoteUp
+	}
+
+	switch {
+	case prev != counted && next == counted:
+		return event, event.Points()
+	case prev == counted && next != counted:
+		return event, -event.Points()
+	}
+	return event, 0
+}
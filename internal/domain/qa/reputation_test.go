@@ -0,0 +1,33 @@
+package qa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVoteReputation(t *testing.T) {
+	cases := []struct {
+		name       string
+		onAnswer   bool
+		prev, next Vote
+		event      ReputationEvent
+		points     int64
+	}{
+		{"Answer upvoted", true, 0, VoteUp, EventAnswerUpvoted, 10},
+		{"Answer upvote withdrawn", true, VoteUp, 0, EventAnswerUpvoted, -10},
+		{"Answer upvote flipped", true, VoteUp, VoteDown, EventAnswerUpvoted, -10},
+		{"Answer downvoted", true, 0, VoteDown, EventAnswerUpvoted, 0},
+		{"Question downvoted", false, 0, VoteDown, EventQuestionDownvoted, -2},
+		{"Question downvote flipped", false, VoteDown, VoteUp, EventQuestionDownvoted, 2},
+		{"Question upvoted", false, 0, VoteUp, EventQuestionDownvoted, 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			event, points := VoteReputation(tc.onAnswer, tc.prev, tc.next)
+			require.Equal(t, tc.event, event)
+			require.Equal(t, tc.points, points)
+		})
+	}
+}
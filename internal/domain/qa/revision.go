@@ -0,0 +1,42 @@
+package qa
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// Revision records the state of a question or an answer before an edit;
+// exactly one of QuestionID and AnswerID is set. For questions PreviousText
+// is the body and PreviousTitle the title; answers have no title.
+type Revision struct {
+	ID            uint64
+	QuestionID    uint64
+	AnswerID      uint64
+	EditorID      uint64
+	PreviousTitle string
+	PreviousText  string
+	CreatedAt     time.Time
+}
+
+// QuestionPatch lists the question fields an edit changes. Nil fields are
+// left as they are.
+type QuestionPatch struct {
+	Title *string
+	Body  *string
+}
+
+func (p QuestionPatch) Empty() bool {
+	return p.Title == nil && p.Body == nil
+}
+
+// AnswerPatch lists the answer fields an edit changes. Nil fields are left
+// as they are.
+type AnswerPatch struct {
+	Text *string
+}
+
+func (p AnswerPatch) Empty() bool {
+	return p.Text == nil
+}
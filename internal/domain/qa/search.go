@@ -0,0 +1,190 @@
+package qa
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"question-answer/pkg/searchquery"
+)
+
+const (
+	MaxSearchQueryLength = 200
+	// SearchLimit caps how many hits a search returns.
+	SearchLimit = 20
+)
+
+// Snippets mark matched terms with these runes so that the caller can escape
+// the text around them before turning them into markup.
+const (
+	SnippetMatchStart = "\u0002"
+	SnippetMatchStop  = "\u0003"
+)
+
+var (
+	ErrInvalidSearch = fmt.Errorf("search query must be 1 to %d characters long", MaxSearchQueryLength)
+	// ErrInvalidSearchQuery wraps the *searchquery.Error locating the
+	// problem.
+	ErrInvalidSearchQuery = errors.New("invalid search query")
+)
+
+// SearchTarget restricts a search to one kind of post.
+type SearchTarget string
+
+const (
+	SearchAll       SearchTarget = ""
+	SearchQuestions SearchTarget = "question"
+	SearchAnswers   SearchTarget = "answer"
+)
+
+// SearchFilter is a parsed search query.
+type SearchFilter struct {
+	// Text holds the words and phrases in web search syntax, as
+	// understood by websearch_to_tsquery. Empty when the query only
+	// filters.
+	Text string
+	// Filter narrows the hits the way it narrows the question list. Its
+	// Tags, HasAnswers and Answered apply to the question a hit belongs
+	// to; its AuthorID and creation time range to the matched post
+	// itself. Its Text, Sort and Desc are not used.
+	Filter QuestionFilter
+	Target SearchTarget
+}
+
+// SearchHit is a question or an answer matching a search query. AnswerID is
+// zero when the question itself matched; Title is always the question's.
+type SearchHit struct {
+	QuestionID uint64
+	AnswerID   uint64
+	Title      string
+	// Snippet is a fragment of the matched text with the matched terms
+	// between SnippetMatchStart and SnippetMatchStop.
+	Snippet   string
+	Rank      float64
+	CreatedAt time.Time
+}
+
+func validateSearchQuery(query string) (string, error) {
+	query = strings.TrimSpace(query)
+	if n := utf8.RuneCountInString(query); n == 0 || n > MaxSearchQueryLength {
+		return "", ErrInvalidSearch
+	}
+	return query, nil
+}
+
+// ParseSearchQuery parses a query such as
+//
+//	tag:go user:42 is:unanswered answers:any created:>2026-01-01 "exact phrase" -exclude
+//
+// into a filter. Errors wrap ErrInvalidSearchQuery and a *searchquery.Error.
+func ParseSearchQuery(s string) (SearchFilter, error) {
+	var f SearchFilter
+
+	q, err := searchquery.Parse(s)
+	if err != nil {
+		return f, fmt.Errorf("%w: %w", ErrInvalidSearchQuery, err)
+	}
+
+	var text []string
+	for _, c := range q.Clauses {
+		switch c := c.(type) {
+		case *searchquery.Term:
+			// Quoting every term keeps words like "or" from being read
+			// as operators.
+			term := `"` + c.Text + `"`
+			if c.Negated {
+				term = "-" + term
+			}
+			text = append(text, term)
+		case *searchquery.Field:
+			if err := f.apply(c); err != nil {
+				return f, fmt.Errorf("%w: %w", ErrInvalidSearchQuery, err)
+			}
+		}
+	}
+	f.Text = strings.Join(text, " ")
+
+	return f, nil
+}
+
+func (f *SearchFilter) apply(c *searchquery.Field) error {
+	switch c.Name {
+	case "tag":
+		tag, err := NormalizeTag(c.Value)
+		if err != nil {
+			return searchquery.NewError(c.ValueCol, "%v", err)
+		}
+		f.Filter.Tags = append(f.Filter.Tags, tag)
+
+	case "user":
+		id, err := strconv.ParseUint(c.Value, 10, 64)
+		if err != nil || id == 0 {
+			return searchquery.NewError(c.ValueCol, "user expects a numeric user id")
+		}
+		f.Filter.AuthorID = id
+
+	case "is":
+		yes, no := true, false
+		// answered means an accepted answer, as for the question list.
+		switch strings.ToLower(c.Value) {
+		case "answered":
+			f.Filter.Answered = &yes
+		case "unanswered":
+			f.Filter.Answered = &no
+		case "question", "answer":
+			target := SearchTarget(strings.ToLower(c.Value))
+			if f.Target != SearchAll && f.Target != target {
+				return searchquery.NewError(c.Col, "is:question and is:answer cannot be combined")
+			}
+			f.Target = target
+		default:
+			return searchquery.NewError(c.ValueCol, "unknown is:%s, expected one of answered, unanswered, question, answer", c.Value)
+		}
+
+	case "answers":
+		yes, no := true, false
+		switch strings.ToLower(c.Value) {
+		case "any":
+			f.Filter.HasAnswers = &yes
+		case "none":
+			f.Filter.HasAnswers = &no
+		default:
+			return searchquery.NewError(c.ValueCol, "unknown answers:%s, expected any or none", c.Value)
+		}
+
+	case "created":
+		day, err := time.Parse(time.DateOnly, c.Value)
+		if err != nil {
+			return searchquery.NewError(c.ValueCol, "created expects a date like 2026-01-01")
+		}
+		next := day.AddDate(0, 0, 1)
+
+		var from, to *time.Time
+		switch c.Op {
+		case searchquery.OpEq:
+			from, to = &day, &next
+		case searchquery.OpGt:
+			from = &next
+		case searchquery.OpGe:
+			from = &day
+		case searchquery.OpLt:
+			to = &day
+		case searchquery.OpLe:
+			to = &next
+		}
+		if from != nil && (f.Filter.CreatedFrom == nil || from.After(*f.Filter.CreatedFrom)) {
+			f.Filter.CreatedFrom = from
+		}
+		if to != nil && (f.Filter.CreatedTo == nil || to.Before(*f.Filter.CreatedTo)) {
+			f.Filter.CreatedTo = to
+		}
+		if f.Filter.CreatedFrom != nil && f.Filter.CreatedTo != nil && !f.Filter.CreatedFrom.Before(*f.Filter.CreatedTo) {
+			return searchquery.NewError(c.Col, "no date satisfies every created filter")
+		}
+	}
+
+	return nil
+}
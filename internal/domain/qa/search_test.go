@@ -0,0 +1,61 @@
+package qa
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"question-answer/pkg/searchquery"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	f, err := ParseSearchQuery(`tag:Go tag:pgx user:42 answers:none is:answer created:>=2026-01-01 created:<2026-02-01 "connection pool" -or`)
+	require.NoError(t, err)
+	require.Equal(t, `"connection pool" -"or"`, f.Text)
+	require.Equal(t, []string{"go", "pgx"}, f.Filter.Tags)
+	require.Equal(t, uint64(42), f.Filter.AuthorID)
+	require.NotNil(t, f.Filter.HasAnswers)
+	require.False(t, *f.Filter.HasAnswers)
+	require.Nil(t, f.Filter.Answered)
+	require.Equal(t, SearchAnswers, f.Target)
+	require.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), *f.Filter.CreatedFrom)
+	require.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), *f.Filter.CreatedTo)
+
+	// is:answered means what answered=true means for the question list.
+	f, err = ParseSearchQuery("created:2026-03-10 is:answered answers:any")
+	require.NoError(t, err)
+	require.Empty(t, f.Text)
+	require.True(t, *f.Filter.Answered)
+	require.True(t, *f.Filter.HasAnswers)
+	require.Equal(t, time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), *f.Filter.CreatedFrom)
+	require.Equal(t, time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC), *f.Filter.CreatedTo)
+
+	f, err = ParseSearchQuery("is:unanswered http://localhost:8082/questions")
+	require.NoError(t, err)
+	require.False(t, *f.Filter.Answered)
+	require.Nil(t, f.Filter.HasAnswers)
+	require.Equal(t, `"http://localhost:8082/questions"`, f.Text)
+}
+
+func TestParseSearchQueryErrors(t *testing.T) {
+	for query, col := range map[string]int{
+		`go "pool`:              4,
+		"go is:closed":          7,
+		"answers:some":          9,
+		"user:bob":              6,
+		"tag:<b>":               5,
+		"created:yesterday":     9,
+		"is:question is:answer": 13,
+		"created:>2026-01-02 created:<2026-01-02": 21,
+		"-tag:go": 1,
+	} {
+		_, err := ParseSearchQuery(query)
+		require.ErrorIs(t, err, ErrInvalidSearchQuery, query)
+
+		var perr *searchquery.Error
+		require.True(t, errors.As(err, &perr), query)
+		require.Equal(t, col, perr.Col, query)
+	}
+}
@@ -1,43 +1,522 @@
 package qa
 
+import "time"
+
 type Service interface {
     // Questions
-    GetAllQuestions() ([]Question, error)
+    // GetAllQuestions returns one page of the questions matching the
+    // filter, in the order it asks for.
+    GetAllQuestions(f QuestionFilter, page PageRequest) (*QuestionPage, error)
     CreateQuestion(q Question) (*Question, error)
-    GetQuestionWithAnswers(id uint64) (*Question, []Answer, error)
-    DeleteQuestion(id uint64) error
+    // GetQuestionWithAnswers returns the question with its answers, each
+    // carrying the vote of viewerID (zero for anonymous viewers). The
+    // accepted answer, if any, comes first.
+    GetQuestionWithAnswers(id, viewerID uint64) (*Question, []Answer, error)
+    DeleteQuestion(id uint64, actor Actor) error
+    // EditQuestion applies the patch and records the previous text as a
+    // revision. Authors and moderators may edit.
+    EditQuestion(id uint64, actor Actor, p QuestionPatch) (*Question, error)
+    GetQuestionRevisions(id uint64) ([]Revision, error)
+    // RollbackQuestion restores the text a revision recorded. The rollback
+    // is itself an edit and gets a revision of its own.
+    RollbackQuestion(id, revisionID uint64, actor Actor) (*Question, error)
+    // RestoreQuestion takes a deleted question and the answers deleted with
+    // it out of the trash.
+    RestoreQuestion(id uint64, actor Actor) (*Question, error)
+    // AcceptAnswer marks answerID as the solution. Only the asker may do so.
+    AcceptAnswer(questionID, answerID uint64, actor Actor) (*Question, error)
+    // MarkDuplicate closes the question as a duplicate of canonicalID.
+    // Moderators only. Questions already closed as duplicates of this one
+    // are pointed at canonicalID too.
+    MarkDuplicate(id, canonicalID uint64, actor Actor) (*Question, error)
+    // Reopen lifts a duplicate closure. Moderators only.
+    Reopen(id uint64, actor Actor) (*Question, error)
 
     // Answers
     CreateAnswer(a Answer) (uint64, error)
     GetAnswer(id uint64) (*Answer, error)
-    DeleteAnswer(id uint64) error
+    DeleteAnswer(id uint64, actor Actor) error
+    RestoreAnswer(id uint64, actor Actor) (*Answer, error)
+    EditAnswer(id uint64, actor Actor, p AnswerPatch) (*Answer, error)
+    GetAnswerRevisions(id uint64) ([]Revision, error)
+    RollbackAnswer(id, revisionID uint64, actor Actor) (*Answer, error)
+
+    // Comments
+    AddComment(c Comment) (*Comment, error)
+    GetQuestionComments(questionID uint64) ([]Comment, error)
+    GetAnswerComments(answerID uint64) ([]Comment, error)
+    DeleteComment(id uint64, actor Actor) error
+
+    // Tags
+    ListTags() ([]Tag, error)
+    // AddTagSynonym makes synonym an alias of tag. Moderators only.
+    AddTagSynonym(tag, synonym string, actor Actor) error
+
+    // Votes
+    Vote(answerID uint64, actor Actor, v Vote) (*Answer, error)
+    Unvote(answerID uint64, actor Actor) (*Answer, error)
+    VoteQuestion(questionID uint64, actor Actor, v Vote) (*Question, error)
+    UnvoteQuestion(questionID uint64, actor Actor) (*Question, error)
+
+    // Reputation. Upvoted and accepted answers earn their authors
+    // reputation, downvoted questions cost some.
+    GetReputation(userID uint64) (int64, error)
+    // RecalculateReputation rebuilds every user's total from the ledger
+    // and reports how many totals were wrong.
+    RecalculateReputation() (int64, error)
+
+    // Trash. Deleted questions and answers stay restorable until purged.
+    ListTrash(actor Actor) (*Trash, error)
+    // PurgeTrash permanently removes content deleted before cutoff and
+    // reports how many questions and answers went.
+    PurgeTrash(cutoff time.Time) (int64, error)
+
+    // SimilarQuestions suggests up to SimilarLimit open questions whose
+    // title or body looks like text, most similar first, leaving out
+    // excludeID.
+    SimilarQuestions(text string, excludeID uint64) ([]SimilarQuestion, error)
+    // Search returns up to SearchLimit questions and answers matching
+    // the query, best matches first. See ParseSearchQuery for the syntax.
+    Search(query string) ([]SearchHit, error)
+
+    // Authorship
+    GetQuestionsByUser(userID uint64) ([]Question, error)
+    GetAnswersByUser(userID uint64) ([]Answer, error)
+}
+
+type Config struct {
+    // DefaultPageSize is the page size of listings that do not ask for
+    // one, DefaultPageSize when zero.
+    DefaultPageSize int
+    // MaxPageSize caps the page size clients may ask for,
+    // DefaultMaxPageSize when zero.
+    MaxPageSize int
 }
 
 type service struct {
     storage Storage
+    cfg     Config
 }
 
-func NewService(storage Storage) Service {
-    return &service{storage: storage}
+func NewService(storage Storage, cfg Config) Service {
+    if cfg.DefaultPageSize <= 0 {
+        cfg.DefaultPageSize = DefaultPageSize
+    }
+    if cfg.MaxPageSize <= 0 {
+        cfg.MaxPageSize = DefaultMaxPageSize
+    }
+    cfg.DefaultPageSize = min(cfg.DefaultPageSize, cfg.MaxPageSize)
+
+    return &service{storage: storage, cfg: cfg}
 }
 
-func (s *service) GetAllQuestions() ([]Question, error) {
-    return s.storage.GetAllQuestions()
+func (s *service) GetAllQuestions(f QuestionFilter, page PageRequest) (*QuestionPage, error) {
+    f, err := f.normalize()
+    if err != nil {
+        return nil, err
+    }
+
+    limit, err := s.pageSize(page.Limit)
+    if err != nil {
+        return nil, err
+    }
+    after, err := DecodeCursor(page.After, f)
+    if err != nil {
+        return nil, err
+    }
+
+    // One extra row tells whether another page follows.
+    questions, err := s.storage.GetAllQuestions(f, after, limit+1)
+    if err != nil {
+        return nil, err
+    }
+
+    res := &QuestionPage{Questions: questions}
+    if len(questions) > limit {
+        res.Questions = questions[:limit]
+        res.NextCursor = cursorAfter(res.Questions[limit-1], f).Encode()
+    }
+
+    return res, nil
+}
+
+func (s *service) pageSize(limit int) (int, error) {
+    switch {
+    case limit < 0:
+        return 0, ErrInvalidPageSize
+    case limit == 0:
+        return s.cfg.DefaultPageSize, nil
+    default:
+        return min(limit, s.cfg.MaxPageSize), nil
+    }
 }
 
 func (s *service) CreateQuestion(q Question) (*Question, error) {
+    if q.UserID == 0 {
+        return nil, ErrAnonymous
+    }
+
+    tags, err := NormalizeTags(q.Tags)
+    if err != nil {
+        return nil, err
+    }
+    if len(tags) > MaxTagsPerQuestion {
+        return nil, ErrTooManyTags
+    }
+    q.Tags = tags
+
     return s.storage.CreateQuestion(q)
 }
 
-func (s *service) GetQuestionWithAnswers(id uint64) (*Question, []Answer, error) {
-    return s.storage.GetQuestionWithAnswers(id)
+func (s *service) GetQuestionWithAnswers(id, viewerID uint64) (*Question, []Answer, error) {
+    q, answers, err := s.storage.GetQuestionWithAnswers(id, viewerID)
+    if err != nil {
+        return nil, nil, err
+    }
+
+    if q.AcceptedAnswerID != 0 {
+        found := false
+        for i := range answers {
+            if answers[i].ID == q.AcceptedAnswerID {
+                answers[i].Accepted = true
+                accepted := answers[i]
+                copy(answers[1:i+1], answers[:i])
+                answers[0] = accepted
+                found = true
+                break
+            }
+        }
+        // The accepted answer is in the trash; it counts again once
+        // restored.
+        if !found {
+            q.AcceptedAnswerID = 0
+        }
+    }
+
+    return q, answers, nil
 }
 
-func (s *service) DeleteQuestion(id uint64) error {
+func (s *service) DeleteQuestion(id uint64, actor Actor) error {
+    q, err := s.storage.GetQuestion(id)
+    if err != nil {
+        return err
+    }
+    if !actor.CanModify(q.UserID) {
+        return ErrForbidden
+    }
     return s.storage.DeleteQuestion(id)
 }
 
+func (s *service) EditQuestion(id uint64, actor Actor, p QuestionPatch) (*Question, error) {
+    if actor.UserID == 0 {
+        return nil, ErrAnonymous
+    }
+
+    q, err := s.storage.GetQuestion(id)
+    if err != nil {
+        return nil, err
+    }
+    if !actor.CanModify(q.UserID) {
+        return nil, ErrForbidden
+    }
+    if p.Title != nil && *p.Title == q.Title {
+        p.Title = nil
+    }
+    if p.Body != nil && *p.Body == q.Body {
+        p.Body = nil
+    }
+    if p.Empty() {
+        return q, nil
+    }
+
+    if err := s.storage.UpdateQuestion(id, actor.UserID, p); err != nil {
+        return nil, err
+    }
+
+    return s.storage.GetQuestion(id)
+}
+
+func (s *service) GetQuestionRevisions(id uint64) ([]Revision, error) {
+    if _, err := s.storage.GetQuestion(id); err != nil {
+        return nil, err
+    }
+    return s.storage.GetQuestionRevisions(id)
+}
+
+func (s *service) RollbackQuestion(id, revisionID uint64, actor Actor) (*Question, error) {
+    rev, err := s.storage.GetRevision(revisionID)
+    if err != nil {
+        return nil, err
+    }
+    if rev.QuestionID != id {
+        return nil, ErrRevisionNotFound
+    }
+
+    return s.EditQuestion(id, actor, QuestionPatch{Title: &rev.PreviousTitle, Body: &rev.PreviousText})
+}
+
+func (s *service) RestoreQuestion(id uint64, actor Actor) (*Question, error) {
+    q, err := s.storage.GetDeletedQuestion(id)
+    if err != nil {
+        return nil, err
+    }
+    if !actor.CanModify(q.UserID) {
+        return nil, ErrForbidden
+    }
+
+    if err := s.storage.RestoreQuestion(id); err != nil {
+        return nil, err
+    }
+
+    return s.storage.GetQuestion(id)
+}
+
+func (s *service) AcceptAnswer(questionID, answerID uint64, actor Actor) (*Question, error) {
+    if actor.UserID == 0 {
+        return nil, ErrAnonymous
+    }
+
+    q, err := s.storage.GetQuestion(questionID)
+    if err != nil {
+        return nil, err
+    }
+    if q.UserID == 0 || q.UserID != actor.UserID {
+        return nil, ErrForbidden
+    }
+
+    a, err := s.storage.GetAnswer(answerID)
+    if err != nil {
+        return nil, err
+    }
+    if a.QuestionID != q.ID {
+        return nil, ErrAnswerMismatch
+    }
+
+    if err := s.storage.SetAcceptedAnswer(q.ID, a.ID); err != nil {
+        return nil, err
+    }
+    q.AcceptedAnswerID = a.ID
+
+    return q, nil
+}
+
+func (s *service) MarkDuplicate(id, canonicalID uint64, actor Actor) (*Question, error) {
+    if actor.UserID == 0 {
+        return nil, ErrAnonymous
+    }
+    if !actor.Privileged {
+        return nil, ErrForbidden
+    }
+
+    if _, err := s.storage.GetQuestion(id); err != nil {
+        return nil, err
+    }
+    canonical, err := s.storage.GetQuestion(canonicalID)
+    if err != nil {
+        return nil, err
+    }
+    // Point straight at the end of the chain so readers need one hop.
+    if canonical.Closed() {
+        canonicalID = canonical.DuplicateOfID
+    }
+    if canonicalID == id {
+        return nil, ErrInvalidDuplicate
+    }
+
+    if err := s.storage.SetDuplicate(id, canonicalID); err != nil {
+        return nil, err
+    }
+
+    return s.storage.GetQuestion(id)
+}
+
+func (s *service) Reopen(id uint64, actor Actor) (*Question, error) {
+    if actor.UserID == 0 {
+        return nil, ErrAnonymous
+    }
+    if !actor.Privileged {
+        return nil, ErrForbidden
+    }
+
+    q, err := s.storage.GetQuestion(id)
+    if err != nil {
+        return nil, err
+    }
+    if !q.Closed() {
+        return q, nil
+    }
+
+    if err := s.storage.SetDuplicate(id, 0); err != nil {
+        return nil, err
+    }
+
+    return s.storage.GetQuestion(id)
+}
+
+func (s *service) RestoreAnswer(id uint64, actor Actor) (*Answer, error) {
+    a, err := s.storage.GetDeletedAnswer(id)
+    if err != nil {
+        return nil, err
+    }
+    if !actor.CanModify(a.UserID) {
+        return nil, ErrForbidden
+    }
+
+    if err := s.storage.RestoreAnswer(id); err != nil {
+        return nil, err
+    }
+
+    return s.storage.GetAnswer(id)
+}
+
+func (s *service) EditAnswer(id uint64, actor Actor, p AnswerPatch) (*Answer, error) {
+    if actor.UserID == 0 {
+        return nil, ErrAnonymous
+    }
+
+    a, err := s.storage.GetAnswer(id)
+    if err != nil {
+        return nil, err
+    }
+    if !actor.CanModify(a.UserID) {
+        return nil, ErrForbidden
+    }
+    if p.Text != nil && *p.Text == a.Text {
+        p.Text = nil
+    }
+    if p.Empty() {
+        return a, nil
+    }
+
+    if err := s.storage.UpdateAnswer(id, actor.UserID, p); err != nil {
+        return nil, err
+    }
+
+    return s.storage.GetAnswer(id)
+}
+
+func (s *service) GetAnswerRevisions(id uint64) ([]Revision, error) {
+    if _, err := s.storage.GetAnswer(id); err != nil {
+        return nil, err
+    }
+    return s.storage.GetAnswerRevisions(id)
+}
+
+func (s *service) RollbackAnswer(id, revisionID uint64, actor Actor) (*Answer, error) {
+    rev, err := s.storage.GetRevision(revisionID)
+    if err != nil {
+        return nil, err
+    }
+    if rev.AnswerID != id {
+        return nil, ErrRevisionNotFound
+    }
+
+    return s.EditAnswer(id, actor, AnswerPatch{Text: &rev.PreviousText})
+}
+
+func (s *service) AddComment(c Comment) (*Comment, error) {
+    if c.UserID == 0 {
+        return nil, ErrAnonymous
+    }
+
+    text, err := validateComment(c.Text)
+    if err != nil {
+        return nil, err
+    }
+    c.Text = text
+
+    switch {
+    case c.QuestionID != 0 && c.AnswerID == 0:
+        if _, err := s.storage.GetQuestion(c.QuestionID); err != nil {
+            return nil, err
+        }
+    case c.AnswerID != 0 && c.QuestionID == 0:
+        if _, err := s.storage.GetAnswer(c.AnswerID); err != nil {
+            return nil, err
+        }
+    default:
+        return nil, ErrCommentTarget
+    }
+
+    return s.storage.CreateComment(c)
+}
+
+func (s *service) GetQuestionComments(questionID uint64) ([]Comment, error) {
+    if _, err := s.storage.GetQuestion(questionID); err != nil {
+        return nil, err
+    }
+    return s.storage.GetQuestionComments(questionID)
+}
+
+func (s *service) GetAnswerComments(answerID uint64) ([]Comment, error) {
+    if _, err := s.storage.GetAnswer(answerID); err != nil {
+        return nil, err
+    }
+    return s.storage.GetAnswerComments(answerID)
+}
+
+func (s *service) DeleteComment(id uint64, actor Actor) error {
+    c, err := s.storage.GetComment(id)
+    if err != nil {
+        return err
+    }
+    if !actor.CanModify(c.UserID) {
+        return ErrForbidden
+    }
+    return s.storage.DeleteComment(id)
+}
+
+func (s *service) ListTrash(actor Actor) (*Trash, error) {
+    if !actor.Privileged {
+        return nil, ErrForbidden
+    }
+    return s.storage.ListTrash()
+}
+
+func (s *service) PurgeTrash(cutoff time.Time) (int64, error) {
+    return s.storage.PurgeDeleted(cutoff)
+}
+
+func (s *service) ListTags() ([]Tag, error) {
+    return s.storage.ListTags()
+}
+
+func (s *service) AddTagSynonym(tag, synonym string, actor Actor) error {
+    if actor.UserID == 0 {
+        return ErrAnonymous
+    }
+    if !actor.Privileged {
+        return ErrForbidden
+    }
+
+    tag, err := NormalizeTag(tag)
+    if err != nil {
+        return err
+    }
+    synonym, err = NormalizeTag(synonym)
+    if err != nil {
+        return err
+    }
+    if tag == synonym {
+        return ErrTagExists
+    }
+
+    return s.storage.AddTagSynonym(tag, synonym)
+}
+
 func (s *service) CreateAnswer(a Answer) (uint64, error) {
+    if a.UserID == 0 {
+        return 0, ErrAnonymous
+    }
+
+    q, err := s.storage.GetQuestion(a.QuestionID)
+    if err != nil {
+        return 0, err
+    }
+    if q.Closed() {
+        return 0, closedError(*q)
+    }
+
     return s.storage.CreateAnswer(a)
 }
 
@@ -45,6 +524,135 @@ func (s *service) GetAnswer(id uint64) (*Answer, error) {
     return s.storage.GetAnswer(id)
 }
 
-func (s *service) DeleteAnswer(id uint64) error {
+func (s *service) DeleteAnswer(id uint64, actor Actor) error {
+    a, err := s.storage.GetAnswer(id)
+    if err != nil {
+        return err
+    }
+    if !actor.CanModify(a.UserID) {
+        return ErrForbidden
+    }
     return s.storage.DeleteAnswer(id)
 }
+
+func (s *service) Vote(answerID uint64, actor Actor, v Vote) (*Answer, error) {
+    if actor.UserID == 0 {
+        return nil, ErrAnonymous
+    }
+    if v != VoteUp && v != VoteDown {
+        return nil, ErrInvalidVote
+    }
+
+    a, err := s.storage.GetAnswer(answerID)
+    if err != nil {
+        return nil, err
+    }
+    if a.UserID == actor.UserID {
+        return nil, ErrSelfVote
+    }
+
+    if a.Score, err = s.storage.SetVote(answerID, actor.UserID, v); err != nil {
+        return nil, err
+    }
+    a.ViewerVote = v
+
+    return a, nil
+}
+
+func (s *service) Unvote(answerID uint64, actor Actor) (*Answer, error) {
+    if actor.UserID == 0 {
+        return nil, ErrAnonymous
+    }
+
+    a, err := s.storage.GetAnswer(answerID)
+    if err != nil {
+        return nil, err
+    }
+
+    if a.Score, err = s.storage.DeleteVote(answerID, actor.UserID); err != nil {
+        return nil, err
+    }
+    a.ViewerVote = 0
+
+    return a, nil
+}
+
+func (s *service) VoteQuestion(questionID uint64, actor Actor, v Vote) (*Question, error) {
+    if actor.UserID == 0 {
+        return nil, ErrAnonymous
+    }
+    if v != VoteUp && v != VoteDown {
+        return nil, ErrInvalidVote
+    }
+
+    q, err := s.storage.GetQuestion(questionID)
+    if err != nil {
+        return nil, err
+    }
+    if q.UserID == actor.UserID {
+        return nil, ErrSelfVote
+    }
+
+    if q.Score, err = s.storage.SetQuestionVote(questionID, actor.UserID, v); err != nil {
+        return nil, err
+    }
+    q.ViewerVote = v
+
+    return q, nil
+}
+
+func (s *service) UnvoteQuestion(questionID uint64, actor Actor) (*Question, error) {
+    if actor.UserID == 0 {
+        return nil, ErrAnonymous
+    }
+
+    q, err := s.storage.GetQuestion(questionID)
+    if err != nil {
+        return nil, err
+    }
+
+    if q.Score, err = s.storage.DeleteQuestionVote(questionID, actor.UserID); err != nil {
+        return nil, err
+    }
+    q.ViewerVote = 0
+
+    return q, nil
+}
+
+func (s *service) GetReputation(userID uint64) (int64, error) {
+    return s.storage.GetReputation(userID)
+}
+
+func (s *service) RecalculateReputation() (int64, error) {
+    return s.storage.RecalculateReputation()
+}
+
+func (s *service) SimilarQuestions(text string, excludeID uint64) ([]SimilarQuestion, error) {
+    text, err := validateSimilarText(text)
+    if err != nil {
+        return nil, err
+    }
+
+    return s.storage.SimilarQuestions(text, excludeID, SimilarLimit)
+}
+
+func (s *service) Search(query string) ([]SearchHit, error) {
+    query, err := validateSearchQuery(query)
+    if err != nil {
+        return nil, err
+    }
+    f, err := ParseSearchQuery(query)
+    if err != nil {
+        return nil, err
+    }
+
+    return s.storage.Search(f, SearchLimit)
+}
+
+func (s *service) GetQuestionsByUser(userID uint64) ([]Question, error) {
+    return s.storage.GetQuestionsByUser(userID)
+}
+
+func (s *service) GetAnswersByUser(userID uint64) ([]Answer, error) {
+    return s.storage.GetAnswersByUser(userID)
+}
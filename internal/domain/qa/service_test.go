@@ -0,0 +1,54 @@
+package qa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// threadStorage serves one question with answers 1..n in ID order.
+type threadStorage struct {
+	Storage
+	acceptedID uint64
+	n          uint64
+}
+
+func (s *threadStorage) GetQuestionWithAnswers(id, _ uint64) (*Question, []Answer, error) {
+	var answers []Answer
+	for aid := uint64(1); aid <= s.n; aid++ {
+		answers = append(answers, Answer{ID: aid, QuestionID: id})
+	}
+	return &Question{ID: id, AcceptedAnswerID: s.acceptedID}, answers, nil
+}
+
+func TestGetQuestionWithAnswersAcceptedFirst(t *testing.T) {
+	cases := []struct {
+		name       string
+		acceptedID uint64
+		order      []uint64
+		accepted   uint64
+	}{
+		{"No accepted answer", 0, []uint64{1, 2, 3, 4}, 0},
+		{"Accepted first already", 1, []uint64{1, 2, 3, 4}, 1},
+		{"Accepted in the middle", 3, []uint64{3, 1, 2, 4}, 3},
+		{"Accepted last", 4, []uint64{4, 1, 2, 3}, 4},
+		{"Accepted answer deleted", 9, []uint64{1, 2, 3, 4}, 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := NewService(&threadStorage{acceptedID: tc.acceptedID, n: 4}, Config{})
+
+			q, answers, err := svc.GetQuestionWithAnswers(7, 0)
+			require.NoError(t, err)
+			require.Equal(t, tc.accepted, q.AcceptedAnswerID)
+
+			var order []uint64
+			for _, a := range answers {
+				order = append(order, a.ID)
+				require.Equal(t, a.ID == tc.accepted, a.Accepted)
+			}
+			require.Equal(t, tc.order, order)
+		})
+	}
+}
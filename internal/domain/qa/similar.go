@@ -0,0 +1,34 @@
+package qa
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	minSimilarTextLength = 3
+	maxSimilarTextLength = 150
+	// SimilarLimit caps how many similar questions are suggested.
+	SimilarLimit = 5
+)
+
+var ErrInvalidSimilarText = fmt.Errorf("text must be %d to %d characters long", minSimilarTextLength, maxSimilarTextLength)
+
+// SimilarQuestion is an existing question whose title or body looks like
+// the text being asked. Score is the trigram similarity, from 0 to 1.
+type SimilarQuestion struct {
+	ID               uint64
+	Title            string
+	AnswerCount      int64
+	AcceptedAnswerID uint64
+	Score            float64
+}
+
+func validateSimilarText(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if n := utf8.RuneCountInString(text); n < minSimilarTextLength || n > maxSimilarTextLength {
+		return "", ErrInvalidSimilarText
+	}
+	return text, nil
+}
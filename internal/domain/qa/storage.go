@@ -1,14 +1,83 @@
 package qa
 
+import "time"
+
+// Storage persists questions and answers. Deleting is soft: deleted rows are
+// invisible to every method except the trash ones below until restored or
+// purged.
 type Storage interface {
     // Questions
-    GetAllQuestions() ([]Question, error)
+    // GetAllQuestions returns up to limit questions matching the filter
+    // that come after the cursor in the filter's order. The filter has
+    // been validated.
+    GetAllQuestions(f QuestionFilter, after Cursor, limit int) ([]Question, error)
     CreateQuestion(q Question) (*Question, error)
-    GetQuestionWithAnswers(id uint64) (*Question, []Answer, error)
+    GetQuestion(id uint64) (*Question, error)
+    GetQuestionWithAnswers(id, viewerID uint64) (*Question, []Answer, error)
     DeleteQuestion(id uint64) error
+    // SetAcceptedAnswer also records the reputation the move of the
+    // accepted mark earns or costs the answer authors.
+    SetAcceptedAnswer(questionID, answerID uint64) error
+    // SetDuplicate closes the question as a duplicate of canonicalID and
+    // repoints questions closed as duplicates of it. Zero reopens it.
+    SetDuplicate(id, canonicalID uint64) error
+    // UpdateQuestion applies the patch and stores the replaced title and body
+    // as a revision by editorID, atomically.
+    UpdateQuestion(id, editorID uint64, p QuestionPatch) error
 
     // Answers
     CreateAnswer(a Answer) (uint64, error)
     GetAnswer(id uint64) (*Answer, error)
     DeleteAnswer(id uint64) error
+    UpdateAnswer(id, editorID uint64, p AnswerPatch) error
+
+    // Revisions, newest first.
+    GetRevision(id uint64) (*Revision, error)
+    GetQuestionRevisions(questionID uint64) ([]Revision, error)
+    GetAnswerRevisions(answerID uint64) ([]Revision, error)
+
+    // Trash. GetDeleted* only find rows that are in the trash.
+    GetDeletedQuestion(id uint64) (*Question, error)
+    GetDeletedAnswer(id uint64) (*Answer, error)
+    RestoreQuestion(id uint64) error
+    RestoreAnswer(id uint64) error
+    ListTrash() (*Trash, error)
+    PurgeDeleted(before time.Time) (int64, error)
+
+    // Comments. GetQuestionWithAnswers also nests comments under the
+    // question and each answer.
+    CreateComment(c Comment) (*Comment, error)
+    GetComment(id uint64) (*Comment, error)
+    GetQuestionComments(questionID uint64) ([]Comment, error)
+    GetAnswerComments(answerID uint64) ([]Comment, error)
+    DeleteComment(id uint64) error
+
+    // Tags. Storage resolves synonyms when attaching and filtering by tags.
+    ListTags() ([]Tag, error)
+    AddTagSynonym(tag, synonym string) error
+
+    // Votes. All return the post's score after the change and record the
+    // reputation the change earns the post's author, atomically.
+    SetVote(answerID, userID uint64, v Vote) (int64, error)
+    DeleteVote(answerID, userID uint64) (int64, error)
+    SetQuestionVote(questionID, userID uint64, v Vote) (int64, error)
+    DeleteQuestionVote(questionID, userID uint64) (int64, error)
+
+    // Reputation. Totals are kept alongside the ledger;
+    // RecalculateReputation rebuilds them from it and reports how many
+    // users changed.
+    GetReputation(userID uint64) (int64, error)
+    RecalculateReputation() (int64, error)
+
+    // SimilarQuestions returns up to limit live questions, other than
+    // excludeID and not closed as duplicates, whose title or body is
+    // similar enough to text by trigrams, most similar first.
+    SimilarQuestions(text string, excludeID uint64, limit int) ([]SimilarQuestion, error)
+    // Search returns the best limit live questions and answers matching
+    // the filter; the newest first when it has no text to rank by.
+    Search(f SearchFilter, limit int) ([]SearchHit, error)
+
+    // Authorship
+    GetQuestionsByUser(userID uint64) ([]Question, error)
+    GetAnswersByUser(userID uint64) ([]Answer, error)
 }
@@ -0,0 +1,76 @@
+package qa
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MaxTagsPerQuestion caps how many tags a question may carry.
+const (
+	MaxTagsPerQuestion = 5
+	maxTagLength       = 32
+)
+
+var (
+	ErrInvalidTag  = errors.New("tag may contain only letters, digits and + # . -")
+	ErrTooManyTags = fmt.Errorf("a question may have at most %d tags", MaxTagsPerQuestion)
+	ErrTagNotFound = errors.New("tag not found")
+	ErrTagExists   = errors.New("tag or synonym already exists")
+)
+
+// Tag is a topic label. Synonyms are alternative names that resolve to it,
+// e.g. "golang" for "go".
+type Tag struct {
+	ID       uint64
+	Name     string
+	Count    int64
+	Synonyms []string
+}
+
+// NormalizeTag returns the canonical spelling of a tag name: lower case,
+// with inner whitespace and underscores collapsed into single dashes.
+func NormalizeTag(name string) (string, error) {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return r == ' ' || r == '\t' || r == '_' || r == '-'
+	})
+	tag := strings.Join(fields, "-")
+
+	if tag == "" || len(tag) > maxTagLength {
+		return "", fmt.Errorf("%w: %q", ErrInvalidTag, name)
+	}
+	for _, r := range tag {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+		case r == '+', r == '#', r == '.', r == '-':
+		default:
+			return "", fmt.Errorf("%w: %q", ErrInvalidTag, name)
+		}
+	}
+
+	return tag, nil
+}
+
+// NormalizeTags normalizes names and drops duplicates, keeping the order of
+// first appearance.
+func NormalizeTags(names []string) ([]string, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	seen := make(map[string]bool, len(names))
+	tags := make([]string, 0, len(names))
+	for _, name := range names {
+		tag, err := NormalizeTag(name)
+		if err != nil {
+			return nil, err
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,19 @@
+package qa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tags, err := NormalizeTags([]string{" Go ", "C#", "node_js", "GO", "Machine  Learning"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"go", "c#", "node-js", "machine-learning"}, tags)
+
+	_, err = NormalizeTags([]string{"go", "<script>"})
+	require.ErrorIs(t, err, ErrInvalidTag)
+
+	_, err = NormalizeTag("   ")
+	require.ErrorIs(t, err, ErrInvalidTag)
+}
@@ -0,0 +1,13 @@
+package qa
+
+import "errors"
+
+var ErrQuestionDeleted = errors.New("the question is deleted, restore it first")
+
+// Trash holds soft-deleted content awaiting restore or purge, most recently
+// deleted first. Answers deleted together with their question are listed
+// as well and come back when the question is restored.
+type Trash struct {
+	Questions []Question
+	Answers   []Answer
+}
@@ -0,0 +1,36 @@
+package qa
+
+import "errors"
+
+var (
+	ErrInvalidVote = errors.New("vote must be up or down")
+	ErrSelfVote    = errors.New("cannot vote on your own post")
+)
+
+// Vote is a user's opinion of a question or an answer. The zero value means no vote.
+type Vote int
+
+const (
+	VoteDown Vote = -1
+	VoteUp   Vote = 1
+)
+
+func ParseVote(s string) (Vote, error) {
+	switch s {
+	case "up":
+		return VoteUp, nil
+	case "down":
+		return VoteDown, nil
+	}
+	return 0, ErrInvalidVote
+}
+
+func (v Vote) String() string {
+	switch v {
+	case VoteUp:
+		return "up"
+	case VoteDown:
+		return "down"
+	}
+	return ""
+}
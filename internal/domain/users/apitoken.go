@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// APITokenPrefix marks personal access tokens so they can be told apart
+// from session JWTs and spotted by secret scanners.
+const APITokenPrefix = "qa_pat_"
+
+var (
+	ErrUnknownScope      = errors.New("unknown scope")
+	ErrAPITokenNotFound  = errors.New("api token not found")
+	ErrAPITokenExpired   = errors.New("api token is expired or revoked")
+	ErrSessionRequired   = errors.New("this action requires an interactive login")
+	ErrInvalidExpiration = errors.New("expiration must be in the future")
+)
+
+type Scope string
+
+const (
+	ScopeQuestionsRead  Scope = "questions:read"
+	ScopeQuestionsWrite Scope = "questions:write"
+	ScopeAnswersRead    Scope = "answers:read"
+	ScopeAnswersWrite   Scope = "answers:write"
+	ScopeCommentsRead   Scope = "comments:read"
+	ScopeCommentsWrite  Scope = "comments:write"
+)
+
+var knownScopes = []Scope{
+	ScopeQuestionsRead,
+	ScopeQuestionsWrite,
+	ScopeAnswersRead,
+	ScopeAnswersWrite,
+	ScopeCommentsRead,
+	ScopeCommentsWrite,
+}
+
+func ParseScope(s string) (Scope, error) {
+	sc := Scope(s)
+	if !slices.Contains(knownScopes, sc) {
+		return "", fmt.Errorf("%w: %q", ErrUnknownScope, s)
+	}
+	return sc, nil
+}
+
+// APIToken is a user-managed credential for automation. Only the hash of
+// the secret is stored; Prefix keeps enough of it to recognise the token
+// in listings.
+type APIToken struct {
+	ID         uint64
+	UserID     uint64
+	Name       string
+	Prefix     string
+	TokenHash  string
+	Scopes     []Scope
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+	RevokedAt  *time.Time
+}
+
+func (t APIToken) Active(now time.Time) bool {
+	return t.RevokedAt == nil && (t.ExpiresAt == nil || now.Before(*t.ExpiresAt))
+}
+
+// HasScope reports whether the principal may use scope. Interactive
+// sessions are not scoped; API tokens carry exactly their granted scopes.
+func (p Principal) HasScope(scope Scope) bool {
+	if p.APITokenID == 0 {
+		return true
+	}
+	return slices.Contains(p.Scopes, scope)
+}
+
+// CreateAPIToken issues a new token for the principal and returns it with
+// the raw secret, which is not retrievable later.
+func (s *service) CreateAPIToken(p Principal, name string, scopes []Scope, expiresAt *time.Time) (*APIToken, string, error) {
+	const op = "auth.service.CreateAPIToken"
+
+	if p.APITokenID != 0 {
+		return nil, "", fmt.Errorf("%s: %w", op, ErrSessionRequired)
+	}
+	if expiresAt != nil && !expiresAt.After(s.now()) {
+		return nil, "", fmt.Errorf("%s: %w", op, ErrInvalidExpiration)
+	}
+
+	secret, hash, err := newOpaqueToken()
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+	raw := APITokenPrefix + secret
+
+	slices.Sort(scopes)
+	t := APIToken{
+		UserID:    p.UserID,
+		Name:      strings.TrimSpace(name),
+		Prefix:    raw[:len(APITokenPrefix)+6],
+		TokenHash: hash,
+		Scopes:    slices.Compact(scopes),
+		ExpiresAt: expiresAt,
+		CreatedAt: s.now(),
+	}
+
+	created, err := s.storage.CreateAPIToken(t)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return created, raw, nil
+}
+
+func (s *service) ListAPITokens(p Principal) ([]APIToken, error) {
+	return s.storage.ListAPITokens(p.UserID)
+}
+
+func (s *service) RevokeAPIToken(p Principal, id uint64) error {
+	return s.storage.RevokeAPIToken(p.UserID, id, s.now())
+}
+
+// authenticateAPIToken resolves a personal access token. The resulting
+// principal carries the token's scopes and only the member role.
+func (s *service) authenticateAPIToken(raw string) (*Principal, error) {
+	secret := strings.TrimPrefix(raw, APITokenPrefix)
+
+	t, err := s.storage.GetAPITokenByHash(hashToken(secret))
+	if errors.Is(err, ErrAPITokenNotFound) {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	if !t.Active(now) {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, ErrAPITokenExpired)
+	}
+
+	u, err := s.storage.GetUserByID(t.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.TouchAPIToken(t.ID, now); err != nil {
+		return nil, err
+	}
+
+	return &Principal{
+		UserID:     u.ID,
+		Username:   u.Username,
+		Roles:      []Role{RoleMember},
+		APITokenID: t.ID,
+		Scopes:     t.Scopes,
+	}, nil
+}
@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type apiTokenStorage struct {
+	*sessionStorage
+	apiTokens map[string]*APIToken
+	touched   int
+}
+
+func (m *apiTokenStorage) CreateAPIToken(t APIToken) (*APIToken, error) {
+	t.ID = uint64(len(m.apiTokens) + 1)
+	m.apiTokens[t.TokenHash] = &t
+	cp := t
+	return &cp, nil
+}
+
+func (m *apiTokenStorage) GetAPITokenByHash(hash string) (*APIToken, error) {
+	t, ok := m.apiTokens[hash]
+	if !ok {
+		return nil, ErrAPITokenNotFound
+	}
+	cp := *t
+	return &cp, nil
+}
+
+func (m *apiTokenStorage) RevokeAPIToken(userID, id uint64, at time.Time) error {
+	for _, t := range m.apiTokens {
+		if t.ID == id && t.UserID == userID {
+			t.RevokedAt = &at
+			return nil
+		}
+	}
+	return ErrAPITokenNotFound
+}
+
+func (m *apiTokenStorage) TouchAPIToken(uint64, time.Time) error {
+	m.touched++
+	return nil
+}
+
+func TestAPITokenAuthenticate(t *testing.T) {
+	st := &apiTokenStorage{sessionStorage: newSessionStorage(), apiTokens: map[string]*APIToken{}}
+	svc := NewService(st, stubTokens{}, nil, Config{}).(*service)
+	owner := Principal{UserID: 7, Username: "gopher", SessionID: "s1"}
+
+	created, raw, err := svc.CreateAPIToken(owner, " ci bot ", []Scope{ScopeQuestionsWrite, ScopeQuestionsRead, ScopeQuestionsWrite}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "ci bot", created.Name)
+	require.Equal(t, []Scope{ScopeQuestionsRead, ScopeQuestionsWrite}, created.Scopes)
+	require.Contains(t, raw, created.Prefix)
+
+	p, err := svc.Authenticate(raw)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), p.UserID)
+	require.True(t, p.HasScope(ScopeQuestionsWrite))
+	require.False(t, p.HasScope(ScopeAnswersWrite))
+	require.Equal(t, 1, st.touched)
+
+	// A token cannot mint further tokens.
+	_, _, err = svc.CreateAPIToken(*p, "nested", nil, nil)
+	require.ErrorIs(t, err, ErrSessionRequired)
+
+	require.NoError(t, svc.RevokeAPIToken(owner, created.ID))
+	_, err = svc.Authenticate(raw)
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	_, err = svc.Authenticate(APITokenPrefix + "unknown")
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestAPITokenExpiry(t *testing.T) {
+	st := &apiTokenStorage{sessionStorage: newSessionStorage(), apiTokens: map[string]*APIToken{}}
+	svc := NewService(st, stubTokens{}, nil, Config{}).(*service)
+	owner := Principal{UserID: 7, SessionID: "s1"}
+
+	past := time.Now().Add(-time.Minute)
+	_, _, err := svc.CreateAPIToken(owner, "old", nil, &past)
+	require.ErrorIs(t, err, ErrInvalidExpiration)
+
+	soon := time.Now().Add(time.Hour)
+	_, raw, err := svc.CreateAPIToken(owner, "short", []Scope{ScopeAnswersRead}, &soon)
+	require.NoError(t, err)
+
+	svc.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+	_, err = svc.Authenticate(raw)
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
@@ -0,0 +1,161 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+var ErrTooManyAttempts = errors.New("too many failed login attempts")
+
+type EventType string
+
+const (
+	EventLogin           EventType = "login"
+	EventLoginFailed     EventType = "login_failed"
+	EventLoginLocked     EventType = "login_locked"
+	EventLogout          EventType = "logout"
+	EventLogoutAll       EventType = "logout_all"
+	EventPasswordChanged EventType = "password_changed"
+)
+
+// Client describes where a request came from for the audit log.
+type Client struct {
+	IP        string
+	UserAgent string
+}
+
+// AuthEvent is an entry of the authentication audit log. UserID is zero
+// when the attempted username does not exist.
+type AuthEvent struct {
+	ID        uint64
+	UserID    uint64
+	Username  string
+	Type      EventType
+	IP        string
+	UserAgent string
+	Detail    string
+	CreatedAt time.Time
+}
+
+type AuthEventFilter struct {
+	UserID   uint64
+	Username string
+	IP       string
+	Type     EventType
+	Limit    int
+}
+
+// FailureStats summarises failed logins since some point in time.
+type FailureStats struct {
+	Count int
+	Last  time.Time
+}
+
+// LockoutPolicy throttles password guessing. After FreeAttempts failures
+// for a username each further attempt must wait BaseDelay, doubling per
+// failure up to MaxDelay. An address with MaxAttemptsPerIP failures is
+// locked out for MaxDelay regardless of the usernames it tries. Only
+// failures within Window count; a zero Window disables the policy.
+type LockoutPolicy struct {
+	Window           time.Duration
+	FreeAttempts     int
+	BaseDelay        time.Duration
+	MaxDelay         time.Duration
+	MaxAttemptsPerIP int
+}
+
+func (l LockoutPolicy) delay(failures int) time.Duration {
+	over := failures - l.FreeAttempts
+	if over <= 0 {
+		return 0
+	}
+	d := l.BaseDelay
+	for i := 1; i < over && d < l.MaxDelay; i++ {
+		d *= 2
+	}
+	return min(d, l.MaxDelay)
+}
+
+// LockedError is returned by Login while the username or address is
+// throttled.
+type LockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("%s, retry in %s", ErrTooManyAttempts, e.RetryAfter.Round(time.Second))
+}
+
+func (e *LockedError) Unwrap() error { return ErrTooManyAttempts }
+
+func (s *service) ListAuthEvents(f AuthEventFilter) ([]AuthEvent, error) {
+	return s.storage.ListAuthEvents(f)
+}
+
+// checkLockout returns a *LockedError when username or ip must wait before
+// trying again.
+func (s *service) checkLockout(username, ip string) error {
+	policy := s.cfg.Lockout
+	if policy.Window <= 0 {
+		return nil
+	}
+
+	now := s.now()
+	since := now.Add(-policy.Window)
+	var wait time.Duration
+
+	byUser, err := s.storage.UserLoginFailures(truncate(username, maxEventUsernameLen), since)
+	if err != nil {
+		return err
+	}
+	if d := policy.delay(byUser.Count); d > 0 {
+		wait = max(wait, byUser.Last.Add(d).Sub(now))
+	}
+
+	if ip != "" && policy.MaxAttemptsPerIP > 0 {
+		byIP, err := s.storage.IPLoginFailures(ip, since)
+		if err != nil {
+			return err
+		}
+		if byIP.Count >= policy.MaxAttemptsPerIP {
+			wait = max(wait, byIP.Last.Add(policy.MaxDelay).Sub(now))
+		}
+	}
+
+	if wait > 0 {
+		return &LockedError{RetryAfter: wait}
+	}
+	return nil
+}
+
+func (s *service) recordEvent(typ EventType, userID uint64, username string, c Client, detail string) error {
+	return s.storage.RecordAuthEvent(AuthEvent{
+		UserID:    userID,
+		Username:  truncate(username, maxEventUsernameLen),
+		Type:      typ,
+		IP:        c.IP,
+		UserAgent: truncate(c.UserAgent, maxUserAgentLen),
+		Detail:    detail,
+		CreatedAt: s.now(),
+	})
+}
+
+// Failed logins record whatever username was typed, so it is cut to the
+// size of auth_events.username like the user agent.
+const (
+	maxUserAgentLen     = 256
+	maxEventUsernameLen = 64
+)
+
+// truncate cuts s to at most n bytes without splitting a UTF-8 sequence.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
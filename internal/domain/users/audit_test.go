@@ -0,0 +1,135 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// lockoutStorage answers the failure queries from the recorded events.
+type lockoutStorage struct {
+	*totpStorage
+}
+
+func (m *lockoutStorage) UserLoginFailures(username string, since time.Time) (FailureStats, error) {
+	var stats FailureStats
+	for _, e := range m.events {
+		if e.Username != username || e.CreatedAt.Before(since) {
+			continue
+		}
+		switch e.Type {
+		case EventLogin:
+			stats = FailureStats{}
+		case EventLoginFailed:
+			stats.Count++
+			stats.Last = e.CreatedAt
+		}
+	}
+	return stats, nil
+}
+
+func (m *lockoutStorage) IPLoginFailures(ip string, since time.Time) (FailureStats, error) {
+	var stats FailureStats
+	for _, e := range m.events {
+		if e.IP == ip && e.Type == EventLoginFailed && !e.CreatedAt.Before(since) {
+			stats.Count++
+			stats.Last = e.CreatedAt
+		}
+	}
+	return stats, nil
+}
+
+func TestLoginLockout(t *testing.T) {
+	st := &lockoutStorage{newTOTPStorage(t)}
+	svc := NewService(st, stubTokens{}, nil, Config{
+		SessionTTL: time.Hour,
+		Lockout: LockoutPolicy{
+			Window:           time.Hour,
+			FreeAttempts:     2,
+			BaseDelay:        time.Second,
+			MaxDelay:         time.Minute,
+			MaxAttemptsPerIP: 100,
+		},
+	}).(*service)
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	client := Client{IP: "192.0.2.1", UserAgent: "curl/8"}
+
+	for range 3 {
+		_, _, err := svc.Login(LoginInput{Username: "mod", Password: "wrong", Client: client})
+		require.ErrorIs(t, err, ErrInvalidCredentials)
+	}
+
+	// Third failure is the first beyond the free attempts: one second.
+	_, _, err := svc.Login(LoginInput{Username: "mod", Password: "s3cret-pass", Client: client})
+	var locked *LockedError
+	require.ErrorAs(t, err, &locked)
+	require.ErrorIs(t, err, ErrTooManyAttempts)
+	require.Equal(t, time.Second, locked.RetryAfter)
+
+	now = now.Add(time.Second)
+	_, _, err = svc.Login(LoginInput{Username: "mod", Password: "wrong", Client: client})
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+
+	// The delay doubles with every further failure.
+	_, _, err = svc.Login(LoginInput{Username: "mod", Password: "s3cret-pass", Client: client})
+	require.ErrorAs(t, err, &locked)
+	require.Equal(t, 2*time.Second, locked.RetryAfter)
+
+	now = now.Add(2 * time.Second)
+	_, _, err = svc.Login(LoginInput{Username: "mod", Password: "s3cret-pass", Client: client})
+	require.NoError(t, err)
+
+	// A successful login resets the per-user counter.
+	_, _, err = svc.Login(LoginInput{Username: "mod", Password: "wrong", Client: client})
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+	_, _, err = svc.Login(LoginInput{Username: "mod", Password: "s3cret-pass", Client: client})
+	require.NoError(t, err)
+
+	var types []EventType
+	for _, e := range st.events {
+		types = append(types, e.Type)
+	}
+	require.Equal(t, []EventType{
+		EventLoginFailed, EventLoginFailed, EventLoginFailed, EventLoginLocked,
+		EventLoginFailed, EventLoginLocked, EventLogin,
+		EventLoginFailed, EventLogin,
+	}, types)
+	require.Equal(t, "curl/8", st.events[0].UserAgent)
+	require.Equal(t, st.user.ID, st.events[0].UserID)
+}
+
+func TestLoginFailureLongUsername(t *testing.T) {
+	st := &lockoutStorage{newTOTPStorage(t)}
+	svc := NewService(st, stubTokens{}, nil, Config{
+		SessionTTL: time.Hour,
+		Lockout:    LockoutPolicy{Window: time.Hour, FreeAttempts: 1, BaseDelay: time.Second, MaxDelay: time.Minute},
+	}).(*service)
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	// 63 ASCII bytes followed by a two-byte rune straddling the limit.
+	username := strings.Repeat("a", 63) + "é" + strings.Repeat("b", 100)
+	for range 2 {
+		_, _, err := svc.Login(LoginInput{Username: username, Password: "wrong"})
+		require.ErrorIs(t, err, ErrInvalidCredentials)
+	}
+	require.Equal(t, strings.Repeat("a", 63), st.events[0].Username)
+
+	// The failures are still counted against the cut name.
+	_, _, err := svc.Login(LoginInput{Username: username, Password: "wrong"})
+	require.ErrorIs(t, err, ErrTooManyAttempts)
+}
+
+func TestLockoutPolicyDelay(t *testing.T) {
+	p := LockoutPolicy{FreeAttempts: 3, BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+
+	require.Zero(t, p.delay(3))
+	require.Equal(t, time.Second, p.delay(4))
+	require.Equal(t, 4*time.Second, p.delay(6))
+	require.Equal(t, 10*time.Second, p.delay(50))
+}
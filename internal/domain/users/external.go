@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrIdentityNotFound = errors.New("external identity is not linked")
+	ErrIdentityLinked   = errors.New("external identity is already linked")
+)
+
+// ExternalIdentity is a user as asserted by an external identity provider.
+// Issuer and Subject together identify the account at the provider.
+type ExternalIdentity struct {
+	Issuer            string
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	PreferredUsername string
+	Name              string
+}
+
+// ExternalLoginInput is a login through an external identity provider.
+type ExternalLoginInput struct {
+	Identity ExternalIdentity
+	// OTP or RecoveryCode is required when the user has enabled
+	// two-factor authentication, as for LoginInput.
+	OTP          string
+	RecoveryCode string
+	Client       Client
+}
+
+const (
+	minUsernameLen = 3
+	maxUsernameLen = 32
+	// numberedUsernames is how many of the attempts at a free derived
+	// username use the suffixes 2, 3, …; the rest use random ones, so the
+	// attempts do not run out however many users share a base name.
+	numberedUsernames = 10
+	usernameAttempts  = 20
+)
+
+// LoginExternal signs in the user linked to the identity. On first login the
+// identity is linked to an existing account with the same verified email, or
+// a new account without a local password is created for it. A user who has
+// enabled two-factor authentication must pass it here too.
+func (s *service) LoginExternal(in ExternalLoginInput) (*User, *Tokens, error) {
+	const op = "auth.service.LoginExternal"
+
+	id, c := in.Identity, in.Client
+
+	u, err := s.storage.GetUserByIdentity(id.Issuer, id.Subject)
+	if errors.Is(err, ErrIdentityNotFound) {
+		u, err = s.linkExternal(id)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if u.TOTPEnabled {
+		if err := s.checkLoginLockout(u.Username, c); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+		err := s.checkSecondFactor(u, in.OTP, in.RecoveryCode)
+		if errors.Is(err, ErrInvalidOTP) {
+			return nil, nil, s.loginFailed(op, u.ID, u.Username, c, "invalid second factor", err)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	u.Roles, err = s.GetUserRoles(u.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tokens, err := s.startSession(u)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.recordEvent(EventLogin, u.ID, u.Username, c, "oidc "+id.Issuer); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return u, tokens, nil
+}
+
+func (s *service) linkExternal(id ExternalIdentity) (*User, error) {
+	email := normalizeEmail(id.Email)
+
+	var u *User
+	var err error
+
+	if email != "" && id.EmailVerified {
+		u, err = s.storage.GetUserByEmail(email)
+		if err != nil && !errors.Is(err, ErrUserNotFound) {
+			return nil, err
+		}
+	}
+
+	if u != nil {
+		err = s.storage.LinkIdentity(u.ID, id.Issuer, id.Subject, email)
+	} else {
+		if !id.EmailVerified {
+			email = ""
+		}
+		u, err = s.createExternalUser(id, email)
+	}
+
+	// A concurrent first login linked the identity first; sign in as the
+	// user it was linked to.
+	if errors.Is(err, ErrIdentityLinked) {
+		return s.storage.GetUserByIdentity(id.Issuer, id.Subject)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// createExternalUser registers an account linked to id under a username
+// derived from the provider's claims, adding a suffix on collisions.
+func (s *service) createExternalUser(id ExternalIdentity, email string) (*User, error) {
+	base := externalUsername(id)
+
+	for i := range usernameAttempts {
+		username := base
+		if i > 0 {
+			suffix := strconv.Itoa(i + 1)
+			if i >= numberedUsernames {
+				suffix = strconv.FormatUint(uint64(rand.Uint32()), 10)
+			}
+			username = base[:min(len(base), maxUsernameLen-len(suffix))] + suffix
+		}
+
+		u, err := s.storage.CreateExternalUser(User{Username: username, Email: email}, id.Issuer, id.Subject, email)
+		if errors.Is(err, ErrUserExists) {
+			continue
+		}
+		return u, err
+	}
+
+	return nil, ErrUserExists
+}
+
+func externalUsername(id ExternalIdentity) string {
+	local, _, _ := strings.Cut(id.Email, "@")
+
+	for _, candidate := range []string{id.PreferredUsername, local, id.Name} {
+		if name := sanitizeUsername(candidate); len(name) >= minUsernameLen {
+			return name
+		}
+	}
+	return "user"
+}
+
+// sanitizeUsername keeps the ASCII letters and digits of s, matching the
+// rules applied to self-registered usernames.
+func sanitizeUsername(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		}
+		if b.Len() == maxUsernameLen {
+			break
+		}
+	}
+	return b.String()
+}
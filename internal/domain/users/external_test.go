@@ -0,0 +1,202 @@
+package auth
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"question-answer/pkg/totp"
+)
+
+type identityStorage struct {
+	*sessionStorage
+	users      []User
+	identities map[string]uint64
+	// beforeCreate runs at the start of CreateExternalUser, letting a test
+	// play a concurrent login.
+	beforeCreate func()
+}
+
+func newIdentityStorage(users ...User) *identityStorage {
+	return &identityStorage{
+		sessionStorage: newSessionStorage(),
+		users:          users,
+		identities:     map[string]uint64{},
+	}
+}
+
+func (m *identityStorage) GetUserByIdentity(issuer, subject string) (*User, error) {
+	id, ok := m.identities[issuer+"|"+subject]
+	if !ok {
+		return nil, ErrIdentityNotFound
+	}
+	for _, u := range m.users {
+		if u.ID == id {
+			return &u, nil
+		}
+	}
+	return nil, ErrUserNotFound
+}
+
+func (m *identityStorage) GetUserByEmail(email string) (*User, error) {
+	for _, u := range m.users {
+		if u.Email != "" && u.Email == email {
+			return &u, nil
+		}
+	}
+	return nil, ErrUserNotFound
+}
+
+func (m *identityStorage) CreateUser(u User) (*User, error) {
+	for _, existing := range m.users {
+		if existing.Username == u.Username {
+			return nil, ErrUserExists
+		}
+	}
+	u.ID = uint64(len(m.users) + 1)
+	m.users = append(m.users, u)
+	return &u, nil
+}
+
+func (m *identityStorage) LinkIdentity(userID uint64, issuer, subject, _ string) error {
+	if _, ok := m.identities[issuer+"|"+subject]; ok {
+		return ErrIdentityLinked
+	}
+	m.identities[issuer+"|"+subject] = userID
+	return nil
+}
+
+func (m *identityStorage) CreateExternalUser(u User, issuer, subject, email string) (*User, error) {
+	if m.beforeCreate != nil {
+		m.beforeCreate()
+	}
+	if _, ok := m.identities[issuer+"|"+subject]; ok {
+		return nil, ErrIdentityLinked
+	}
+	created, err := m.CreateUser(u)
+	if err != nil {
+		return nil, err
+	}
+	return created, m.LinkIdentity(created.ID, issuer, subject, email)
+}
+
+func (m *identityStorage) AdvanceTOTPStep(uint64, int64) (bool, error) { return true, nil }
+
+func TestLoginExternalCreatesUser(t *testing.T) {
+	st := newIdentityStorage(User{ID: 1, Username: "gopher"})
+	svc := NewService(st, stubTokens{}, nil, Config{}).(*service)
+
+	id := ExternalIdentity{
+		Issuer:            "https://sso.example.com",
+		Subject:           "abc",
+		Email:             "gopher@example.com",
+		PreferredUsername: "go.pher",
+	}
+
+	u, tokens, err := svc.LoginExternal(ExternalLoginInput{Identity: id})
+	require.NoError(t, err)
+	require.NotEmpty(t, tokens.AccessToken)
+	require.Equal(t, "gopher2", u.Username)
+	require.Empty(t, u.Email, "unverified email must not be stored")
+	require.Empty(t, u.PasswordHash)
+
+	again, _, err := svc.LoginExternal(ExternalLoginInput{Identity: id})
+	require.NoError(t, err)
+	require.Equal(t, u.ID, again.ID)
+	require.Len(t, st.users, 2)
+	require.Equal(t, EventLogin, st.events[len(st.events)-1].Type)
+}
+
+func TestLoginExternalLinksVerifiedEmail(t *testing.T) {
+	st := newIdentityStorage(User{ID: 1, Username: "gopher", Email: "gopher@example.com"})
+	svc := NewService(st, stubTokens{}, nil, Config{}).(*service)
+
+	u, _, err := svc.LoginExternal(ExternalLoginInput{Identity: ExternalIdentity{
+		Issuer:        "https://sso.example.com",
+		Subject:       "abc",
+		Email:         "Gopher@Example.com",
+		EmailVerified: true,
+	}})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), u.ID)
+	require.Equal(t, uint64(1), st.identities["https://sso.example.com|abc"])
+}
+
+func TestLoginExternalRequiresSecondFactor(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+
+	st := newIdentityStorage(User{ID: 1, Username: "admin", Email: "admin@example.com", TOTPEnabled: true, TOTPSecret: secret})
+	svc := NewService(st, stubTokens{}, nil, Config{SessionTTL: time.Hour}).(*service)
+
+	id := ExternalIdentity{
+		Issuer:        "https://sso.example.com",
+		Subject:       "abc",
+		Email:         "admin@example.com",
+		EmailVerified: true,
+	}
+
+	_, _, err = svc.LoginExternal(ExternalLoginInput{Identity: id})
+	require.ErrorIs(t, err, ErrOTPRequired)
+	require.Empty(t, st.sessions)
+
+	_, _, err = svc.LoginExternal(ExternalLoginInput{Identity: id, OTP: "000000"})
+	require.ErrorIs(t, err, ErrInvalidOTP)
+	require.Equal(t, EventLoginFailed, st.events[len(st.events)-1].Type)
+
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	require.NoError(t, err)
+
+	// The identity was linked by the first attempt; only the code is needed.
+	u, tokens, err := svc.LoginExternal(ExternalLoginInput{
+		Identity: ExternalIdentity{Issuer: id.Issuer, Subject: id.Subject},
+		OTP:      code,
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), u.ID)
+	require.NotEmpty(t, tokens.AccessToken)
+}
+
+func TestLoginExternalUsernameSuffixes(t *testing.T) {
+	users := []User{{ID: 1, Username: "user"}}
+	for i := 2; i <= usernameAttempts; i++ {
+		users = append(users, User{ID: uint64(i), Username: fmt.Sprintf("user%d", i)})
+	}
+	st := newIdentityStorage(users...)
+	svc := NewService(st, stubTokens{}, nil, Config{}).(*service)
+
+	u, _, err := svc.LoginExternal(ExternalLoginInput{Identity: ExternalIdentity{Issuer: "https://sso.example.com", Subject: "abc"}})
+	require.NoError(t, err)
+	require.Regexp(t, `^user\d+$`, u.Username)
+	require.Len(t, st.users, usernameAttempts+1)
+}
+
+func TestLoginExternalConcurrentFirstLogin(t *testing.T) {
+	st := newIdentityStorage(User{ID: 1, Username: "gopher"})
+	svc := NewService(st, stubTokens{}, nil, Config{}).(*service)
+
+	// Another login of the same identity wins the race to create the user.
+	st.beforeCreate = func() {
+		st.beforeCreate = nil
+		winner, err := st.CreateExternalUser(User{Username: "gopher2"}, "https://sso.example.com", "abc", "")
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), winner.ID)
+	}
+
+	u, _, err := svc.LoginExternal(ExternalLoginInput{Identity: ExternalIdentity{
+		Issuer:            "https://sso.example.com",
+		Subject:           "abc",
+		PreferredUsername: "gopher",
+	}})
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), u.ID)
+	require.Len(t, st.users, 2, "the losing login must not leave a user behind")
+}
+
+func TestExternalUsername(t *testing.T) {
+	require.Equal(t, "jdoe", externalUsername(ExternalIdentity{PreferredUsername: "j.doe"}))
+	require.Equal(t, "alice", externalUsername(ExternalIdentity{PreferredUsername: "al", Email: "alice@example.com"}))
+	require.Equal(t, "user", externalUsername(ExternalIdentity{Name: "Жанна"}))
+}
@@ -1,8 +1,15 @@
-//Pacakge auth provides
+// Package auth provides domain models and services for user accounts.
 package auth
 
+import "time"
+
 type User struct {
-	ID           uint64 `json:"id"`
-	Username     string `json:"username" validate:"required,min=3,max=32"`
-	PasswordHash string `json:"-"`
+	ID           uint64    `json:"id"`
+	Username     string    `json:"username" validate:"required,min=3,max=32"`
+	Email        string    `json:"-"`
+	PasswordHash string    `json:"-"`
+	TOTPSecret   string    `json:"-"`
+	TOTPEnabled  bool      `json:"two_factor_enabled"`
+	Roles        []Role    `json:"roles"`
+	CreatedAt    time.Time `json:"created_at"`
 }
@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var ErrInvalidResetToken = errors.New("invalid or expired password reset token")
+
+// Mailer delivers plain-text e-mail. It is implemented by the SMTP and
+// outbox mailers of internal/infrastructure/mail.
+type Mailer interface {
+	Send(to, subject, body string) error
+}
+
+type PasswordReset struct {
+	UserID    uint64
+	TokenHash string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
+
+// ForgotPassword mails a single-use reset token to the account registered
+// under email. Unknown addresses are ignored so the endpoint cannot be used
+// to probe which addresses have accounts.
+func (s *service) ForgotPassword(email string) error {
+	const op = "auth.service.ForgotPassword"
+
+	u, err := s.storage.GetUserByEmail(normalizeEmail(email))
+	if errors.Is(err, ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	raw, hash, err := newOpaqueToken()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	now := s.now()
+	reset := PasswordReset{
+		UserID:    u.ID,
+		TokenHash: hash,
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.cfg.ResetTTL),
+	}
+	if err := s.storage.CreatePasswordReset(reset); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.mailer.Send(u.Email, "Password reset", s.resetMailBody(u, raw, reset.ExpiresAt)); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ResetPassword sets a new password using a token from ForgotPassword. The
+// token is consumed, and every session of the user is revoked.
+func (s *service) ResetPassword(token, newPassword string, c Client) error {
+	const op = "auth.service.ResetPassword"
+
+	reset, err := s.storage.GetPasswordReset(hashToken(token))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	now := s.now()
+	if reset.UsedAt != nil || !now.Before(reset.ExpiresAt) {
+		return fmt.Errorf("%s: %w", op, ErrInvalidResetToken)
+	}
+
+	consumed, err := s.storage.MarkPasswordResetUsed(reset.TokenHash, now)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !consumed {
+		return fmt.Errorf("%s: %w", op, ErrInvalidResetToken)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.storage.UpdatePassword(reset.UserID, string(hash)); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.storage.RevokeUserSessions(reset.UserID, now); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.recordEvent(EventPasswordChanged, reset.UserID, "", c, "password reset"); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *service) resetMailBody(u *User, token string, expiresAt time.Time) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Hello, %s!\n\n", u.Username)
+	b.WriteString("Someone requested a password reset for your account.\n")
+	if s.cfg.ResetURL != "" {
+		fmt.Fprintf(&b, "Open this link to choose a new password:\n%s\n\n", strings.ReplaceAll(s.cfg.ResetURL, "{token}", token))
+	} else {
+		fmt.Fprintf(&b, "Use this token to choose a new password:\n%s\n\n", token)
+	}
+	fmt.Fprintf(&b, "The token expires at %s and can be used once.\n", expiresAt.UTC().Format(time.RFC1123))
+	b.WriteString("If you did not request this, ignore this message.\n")
+
+	return b.String()
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type resetStorage struct {
+	Storage
+	user     User
+	resets   map[string]*PasswordReset
+	revoked  bool
+	password string
+	events   []AuthEvent
+}
+
+func (m *resetStorage) GetUserByEmail(email string) (*User, error) {
+	if email != m.user.Email {
+		return nil, ErrUserNotFound
+	}
+	u := m.user
+	return &u, nil
+}
+
+func (m *resetStorage) CreatePasswordReset(r PasswordReset) error {
+	m.resets[r.TokenHash] = &r
+	return nil
+}
+
+func (m *resetStorage) GetPasswordReset(hash string) (*PasswordReset, error) {
+	r, ok := m.resets[hash]
+	if !ok {
+		return nil, ErrInvalidResetToken
+	}
+	cp := *r
+	return &cp, nil
+}
+
+func (m *resetStorage) MarkPasswordResetUsed(hash string, at time.Time) (bool, error) {
+	r := m.resets[hash]
+	if r.UsedAt != nil {
+		return false, nil
+	}
+	r.UsedAt = &at
+	return true, nil
+}
+
+func (m *resetStorage) UpdatePassword(_ uint64, hash string) error {
+	m.password = hash
+	return nil
+}
+
+func (m *resetStorage) RevokeUserSessions(uint64, time.Time) error {
+	m.revoked = true
+	return nil
+}
+
+func (m *resetStorage) RecordAuthEvent(e AuthEvent) error {
+	m.events = append(m.events, e)
+	return nil
+}
+
+type captureMailer struct {
+	to, body string
+	sent     int
+}
+
+func (m *captureMailer) Send(to, _, body string) error {
+	m.to, m.body = to, body
+	m.sent++
+	return nil
+}
+
+// token pulls the reset token out of the mailed link.
+func (m *captureMailer) token(t *testing.T) string {
+	t.Helper()
+	_, rest, ok := strings.Cut(m.body, "?token=")
+	require.True(t, ok, "no token in mail body")
+	tok, _, _ := strings.Cut(rest, "\n")
+	return tok
+}
+
+func TestPasswordReset(t *testing.T) {
+	st := &resetStorage{
+		user:   User{ID: 3, Username: "gopher", Email: "gopher@example.com"},
+		resets: map[string]*PasswordReset{},
+	}
+	mailer := &captureMailer{}
+	svc := NewService(st, stubTokens{}, mailer, Config{
+		ResetTTL: time.Hour,
+		ResetURL: "https://qa.example.com/reset?token={token}",
+	}).(*service)
+
+	require.NoError(t, svc.ForgotPassword("unknown@example.com"))
+	require.Zero(t, mailer.sent)
+
+	require.NoError(t, svc.ForgotPassword("  Gopher@Example.com "))
+	require.Equal(t, 1, mailer.sent)
+	require.Equal(t, "gopher@example.com", mailer.to)
+
+	token := mailer.token(t)
+
+	require.NoError(t, svc.ResetPassword(token, "brand-new-pass", Client{IP: "192.0.2.1"}))
+	require.NotEmpty(t, st.password)
+	require.True(t, st.revoked)
+	require.Len(t, st.events, 1)
+	require.Equal(t, EventPasswordChanged, st.events[0].Type)
+
+	err := svc.ResetPassword(token, "another-pass", Client{})
+	require.ErrorIs(t, err, ErrInvalidResetToken)
+}
+
+func TestPasswordResetExpired(t *testing.T) {
+	st := &resetStorage{
+		user:   User{ID: 3, Username: "gopher", Email: "gopher@example.com"},
+		resets: map[string]*PasswordReset{},
+	}
+	mailer := &captureMailer{}
+	svc := NewService(st, stubTokens{}, mailer, Config{
+		ResetTTL: time.Hour,
+		ResetURL: "https://qa.example.com/reset?token={token}",
+	}).(*service)
+
+	require.NoError(t, svc.ForgotPassword("gopher@example.com"))
+
+	svc.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+	err := svc.ResetPassword(mailer.token(t), "brand-new-pass", Client{})
+	require.ErrorIs(t, err, ErrInvalidResetToken)
+	require.Empty(t, st.password)
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"errors"
+	"slices"
+)
+
+var (
+	ErrUnknownRole         = errors.New("unknown role")
+	ErrRoleNotAssignable   = errors.New("role cannot be granted or revoked")
+	ErrCannotRevokeOwnRole = errors.New("admins cannot revoke their own admin role")
+)
+
+type Role string
+
+const (
+	RoleAdmin     Role = "admin"
+	RoleModerator Role = "moderator"
+	// RoleMember is held implicitly by every registered user and is never
+	// stored as an assignment.
+	RoleMember Role = "member"
+)
+
+type Permission string
+
+const (
+	// PermModerateContent allows deleting and editing content authored by
+	// other users.
+	PermModerateContent Permission = "content:moderate"
+	// PermManageRoles allows granting and revoking roles.
+	PermManageRoles Permission = "roles:manage"
+	// PermViewAuditLog allows reading the authentication event log.
+	PermViewAuditLog Permission = "audit:read"
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleAdmin:     {PermModerateContent, PermManageRoles, PermViewAuditLog},
+	RoleModerator: {PermModerateContent},
+	RoleMember:    {},
+}
+
+func ParseRole(s string) (Role, error) {
+	r := Role(s)
+	if _, ok := rolePermissions[r]; !ok {
+		return "", ErrUnknownRole
+	}
+	return r, nil
+}
+
+func (r Role) Has(perm Permission) bool {
+	return slices.Contains(rolePermissions[r], perm)
+}
+
+// Privileged reports whether the role grants anything beyond membership.
+func (r Role) Privileged() bool {
+	return len(rolePermissions[r]) > 0
+}
+
+// HasPermission reports whether any of the principal's roles grants perm.
+func (p Principal) HasPermission(perm Permission) bool {
+	for _, r := range p.Roles {
+		if r.Has(perm) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,19 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestDummyPasswordHash(t *testing.T) {
+	// A malformed hash fails before hashing anything, which would make
+	// unknown usernames answer faster than wrong passwords.
+	cost, err := bcrypt.Cost(dummyPasswordHash)
+	require.NoError(t, err)
+	require.Equal(t, bcrypt.DefaultCost, cost)
+
+	err = bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte("s3cret-pass"))
+	require.ErrorIs(t, err, bcrypt.ErrMismatchedHashAndPassword)
+}
@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reuse detected, session revoked")
+	ErrSessionRevoked      = errors.New("session is revoked or expired")
+	ErrSessionNotFound     = errors.New("session not found")
+)
+
+// Session is a login on one device. Each refresh rotates the session's
+// refresh token; presenting an already rotated token revokes the session.
+type Session struct {
+	ID        string
+	UserID    uint64
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+}
+
+func (s Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+type RefreshToken struct {
+	SessionID string
+	TokenHash string
+	CreatedAt time.Time
+	UsedAt    *time.Time
+}
+
+func (s *service) Refresh(refreshToken string) (*Tokens, error) {
+	const op = "auth.service.Refresh"
+
+	tok, err := s.storage.GetRefreshToken(hashToken(refreshToken))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	sess, err := s.storage.GetSession(tok.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	now := s.now()
+	if !sess.Active(now) {
+		return nil, fmt.Errorf("%s: %w", op, ErrSessionRevoked)
+	}
+
+	// A token that was already exchanged has leaked: whoever holds the
+	// newer token is unknown, so the whole session is cut off.
+	fresh := tok.UsedAt == nil
+	if fresh {
+		if fresh, err = s.storage.MarkRefreshTokenUsed(tok.TokenHash, now); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if !fresh {
+		if err := s.storage.RevokeSession(sess.ID, now); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		return nil, fmt.Errorf("%s: %w", op, ErrRefreshTokenReused)
+	}
+
+	u, err := s.GetUser(sess.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tokens, err := s.issueTokens(u, *sess)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tokens, nil
+}
+
+func (s *service) Logout(p Principal, c Client) error {
+	if p.SessionID == "" {
+		return ErrSessionNotFound
+	}
+	if err := s.storage.RevokeSession(p.SessionID, s.now()); err != nil {
+		return err
+	}
+	return s.recordEvent(EventLogout, p.UserID, p.Username, c, "")
+}
+
+func (s *service) LogoutAll(p Principal, c Client) error {
+	if err := s.storage.RevokeUserSessions(p.UserID, s.now()); err != nil {
+		return err
+	}
+	return s.recordEvent(EventLogoutAll, p.UserID, p.Username, c, "")
+}
+
+// startSession opens a new session for u and issues its first token pair.
+func (s *service) startSession(u *User) (*Tokens, error) {
+	now := s.now()
+	sess := Session{
+		ID:        uuid.NewString(),
+		UserID:    u.ID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.cfg.SessionTTL),
+	}
+
+	if err := s.storage.CreateSession(sess); err != nil {
+		return nil, err
+	}
+
+	return s.issueTokens(u, sess)
+}
+
+// issueTokens signs an access token bound to sess and stores a new refresh
+// token for it.
+func (s *service) issueTokens(u *User, sess Session) (*Tokens, error) {
+	raw, hash, err := newOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.AddRefreshToken(RefreshToken{
+		SessionID: sess.ID,
+		TokenHash: hash,
+		CreatedAt: s.now(),
+	}); err != nil {
+		return nil, err
+	}
+
+	access, exp, err := s.tokens.Issue(Principal{
+		UserID:    u.ID,
+		Username:  u.Username,
+		Roles:     s.effectiveRoles(u),
+		SessionID: sess.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Tokens{
+		AccessToken:            access,
+		ExpiresAt:              exp,
+		RefreshToken:           raw,
+		RefreshExpiresAt:       sess.ExpiresAt,
+		TwoFactorSetupRequired: s.requiresTOTPSetup(u),
+	}, nil
+}
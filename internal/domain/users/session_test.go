@@ -0,0 +1,177 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// sessionStorage keeps sessions and refresh tokens in memory. Methods the
+// tests do not exercise fall through to the nil embedded Storage and panic.
+type sessionStorage struct {
+	Storage
+	sessions map[string]*Session
+	tokens   map[string]*RefreshToken
+	events   []AuthEvent
+	roles    []Role
+	// totpEnabled is reported for every user.
+	totpEnabled bool
+}
+
+func newSessionStorage() *sessionStorage {
+	return &sessionStorage{
+		sessions: map[string]*Session{},
+		tokens:   map[string]*RefreshToken{},
+	}
+}
+
+func (m *sessionStorage) GetUserByID(id uint64) (*User, error) {
+	return &User{ID: id, Username: "gopher", TOTPEnabled: m.totpEnabled}, nil
+}
+
+func (m *sessionStorage) GetUserRoles(uint64) ([]Role, error) { return m.roles, nil }
+
+func (m *sessionStorage) CreateSession(sess Session) error {
+	m.sessions[sess.ID] = &sess
+	return nil
+}
+
+func (m *sessionStorage) GetSession(id string) (*Session, error) {
+	sess, ok := m.sessions[id]
+	if !ok {
+		return nil, ErrSessionNotFound
+	}
+	cp := *sess
+	return &cp, nil
+}
+
+func (m *sessionStorage) RevokeSession(id string, at time.Time) error {
+	m.sessions[id].RevokedAt = &at
+	return nil
+}
+
+func (m *sessionStorage) AddRefreshToken(t RefreshToken) error {
+	m.tokens[t.TokenHash] = &t
+	return nil
+}
+
+func (m *sessionStorage) GetRefreshToken(hash string) (*RefreshToken, error) {
+	t, ok := m.tokens[hash]
+	if !ok {
+		return nil, ErrInvalidRefreshToken
+	}
+	cp := *t
+	return &cp, nil
+}
+
+func (m *sessionStorage) MarkRefreshTokenUsed(hash string, at time.Time) (bool, error) {
+	t := m.tokens[hash]
+	if t.UsedAt != nil {
+		return false, nil
+	}
+	t.UsedAt = &at
+	return true, nil
+}
+
+func (m *sessionStorage) RecordAuthEvent(e AuthEvent) error {
+	m.events = append(m.events, e)
+	return nil
+}
+
+type stubTokens struct{}
+
+func (stubTokens) Issue(p Principal) (string, time.Time, error) {
+	return "access-" + p.SessionID, time.Now().Add(time.Minute), nil
+}
+
+func (stubTokens) Parse(string) (*Principal, error) { return nil, ErrInvalidToken }
+
+func TestRefreshRotation(t *testing.T) {
+	st := newSessionStorage()
+	svc := NewService(st, stubTokens{}, nil, Config{SessionTTL: time.Hour}).(*service)
+
+	first, err := svc.startSession(&User{ID: 1, Username: "gopher"})
+	require.NoError(t, err)
+
+	second, err := svc.Refresh(first.RefreshToken)
+	require.NoError(t, err)
+	require.NotEqual(t, first.RefreshToken, second.RefreshToken)
+
+	third, err := svc.Refresh(second.RefreshToken)
+	require.NoError(t, err)
+
+	// Replaying a rotated token revokes the session, so even the newest
+	// token stops working.
+	_, err = svc.Refresh(first.RefreshToken)
+	require.ErrorIs(t, err, ErrRefreshTokenReused)
+
+	_, err = svc.Refresh(third.RefreshToken)
+	require.ErrorIs(t, err, ErrSessionRevoked)
+
+	_, err = svc.Refresh("unknown")
+	require.ErrorIs(t, err, ErrInvalidRefreshToken)
+}
+
+func TestRefreshExpiredSession(t *testing.T) {
+	st := newSessionStorage()
+	svc := NewService(st, stubTokens{}, nil, Config{SessionTTL: time.Hour}).(*service)
+
+	tokens, err := svc.startSession(&User{ID: 1})
+	require.NoError(t, err)
+
+	svc.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+	_, err = svc.Refresh(tokens.RefreshToken)
+	require.ErrorIs(t, err, ErrSessionRevoked)
+}
+
+// claimTokens parses every access token into the principal it holds.
+type claimTokens struct{ p Principal }
+
+func (t claimTokens) Issue(Principal) (string, time.Time, error) {
+	return "access", time.Now().Add(time.Minute), nil
+}
+
+func (t claimTokens) Parse(string) (*Principal, error) {
+	p := t.p
+	return &p, nil
+}
+
+func TestAuthenticateReadsCurrentRoles(t *testing.T) {
+	st := newSessionStorage()
+	st.sessions["s1"] = &Session{ID: "s1", UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}
+
+	// The token still claims the admin role, which has since been revoked.
+	tokens := claimTokens{p: Principal{UserID: 1, SessionID: "s1", Roles: []Role{RoleAdmin, RoleMember}}}
+	svc := NewService(st, tokens, nil, Config{SessionTTL: time.Hour})
+
+	p, err := svc.Authenticate("access")
+	require.NoError(t, err)
+	require.Equal(t, []Role{RoleMember}, p.Roles)
+
+	st.roles = []Role{RoleModerator}
+	p, err = svc.Authenticate("access")
+	require.NoError(t, err)
+	require.Equal(t, []Role{RoleModerator, RoleMember}, p.Roles)
+}
+
+func TestAuthenticateWithholdsRolesWithoutTOTP(t *testing.T) {
+	st := newSessionStorage()
+	st.sessions["s1"] = &Session{ID: "s1", UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}
+	st.roles = []Role{RoleAdmin, RoleModerator}
+
+	// The login token held member only; the stored roles must not bring
+	// the privileged ones back before TOTP is enabled.
+	tokens := claimTokens{p: Principal{UserID: 1, SessionID: "s1", Roles: []Role{RoleMember}}}
+	svc := NewService(st, tokens, nil, Config{SessionTTL: time.Hour, Require2FAForPrivileged: true})
+
+	p, err := svc.Authenticate("access")
+	require.NoError(t, err)
+	require.Equal(t, []Role{RoleMember}, p.Roles)
+
+	st.totpEnabled = true
+	p, err = svc.Authenticate("access")
+	require.NoError(t, err)
+	require.Equal(t, []Role{RoleAdmin, RoleModerator, RoleMember}, p.Roles)
+}
@@ -0,0 +1,70 @@
+package auth
+
+import "time"
+
+type Storage interface {
+	CreateUser(u User) (*User, error)
+	GetUserByID(id uint64) (*User, error)
+	GetUserByUsername(username string) (*User, error)
+	GetUserByEmail(email string) (*User, error)
+	UpdatePassword(userID uint64, passwordHash string) error
+
+	// Roles
+	GetUserRoles(userID uint64) ([]Role, error)
+	GrantRole(userID uint64, role Role, grantedBy uint64) error
+	RevokeRole(userID uint64, role Role) error
+
+	// Sessions
+	CreateSession(sess Session) error
+	GetSession(id string) (*Session, error)
+	RevokeSession(id string, at time.Time) error
+	RevokeUserSessions(userID uint64, at time.Time) error
+	AddRefreshToken(t RefreshToken) error
+	GetRefreshToken(hash string) (*RefreshToken, error)
+	// MarkRefreshTokenUsed sets used_at on an unused token and reports
+	// whether this call was the one that did it.
+	MarkRefreshTokenUsed(hash string, at time.Time) (bool, error)
+
+	// Password resets
+	CreatePasswordReset(r PasswordReset) error
+	GetPasswordReset(hash string) (*PasswordReset, error)
+	// MarkPasswordResetUsed consumes an unused reset token and reports
+	// whether this call was the one that did it.
+	MarkPasswordResetUsed(hash string, at time.Time) (bool, error)
+
+	// Two-factor authentication
+	SetTOTPSecret(userID uint64, secret string) error
+	// EnableTOTP turns the pending secret on and replaces the user's
+	// recovery codes with the given hashes.
+	EnableTOTP(userID uint64, recoveryCodeHashes []string) error
+	// DisableTOTP clears the secret and deletes the recovery codes.
+	DisableTOTP(userID uint64) error
+	// AdvanceTOTPStep records step as the last accepted one if it is newer
+	// than the stored step, and reports whether it was.
+	AdvanceTOTPStep(userID uint64, step int64) (bool, error)
+	UseRecoveryCode(userID uint64, hash string, at time.Time) (bool, error)
+
+	// API tokens
+	CreateAPIToken(t APIToken) (*APIToken, error)
+	GetAPITokenByHash(hash string) (*APIToken, error)
+	ListAPITokens(userID uint64) ([]APIToken, error)
+	RevokeAPIToken(userID, id uint64, at time.Time) error
+	// TouchAPIToken records that the token was just used.
+	TouchAPIToken(id uint64, at time.Time) error
+
+	// Audit log
+	RecordAuthEvent(e AuthEvent) error
+	ListAuthEvents(f AuthEventFilter) ([]AuthEvent, error)
+	// UserLoginFailures counts failed logins for username since the later
+	// of since and its last successful login.
+	UserLoginFailures(username string, since time.Time) (FailureStats, error)
+	IPLoginFailures(ip string, since time.Time) (FailureStats, error)
+
+	// External identities
+	GetUserByIdentity(issuer, subject string) (*User, error)
+	// LinkIdentity and CreateExternalUser return ErrIdentityLinked when
+	// the identity is linked already. CreateExternalUser creates the user
+	// and links the identity to it atomically.
+	LinkIdentity(userID uint64, issuer, subject, email string) error
+	CreateExternalUser(u User, issuer, subject, email string) (*User, error)
+}
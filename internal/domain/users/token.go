@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+// Principal is the authenticated caller attached to a request.
+type Principal struct {
+	UserID    uint64
+	Username  string
+	Roles     []Role
+	SessionID string
+
+	// APITokenID is set when the caller authenticated with a personal
+	// access token, which limits it to Scopes.
+	APITokenID uint64
+	Scopes     []Scope
+}
+
+type Tokens struct {
+	AccessToken      string
+	ExpiresAt        time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+	// TwoFactorSetupRequired is set when privileged roles were withheld
+	// from the tokens until the user enables two-factor authentication.
+	TwoFactorSetupRequired bool
+}
+
+type TokenManager interface {
+	Issue(p Principal) (token string, expiresAt time.Time, err error)
+	Parse(token string) (*Principal, error)
+}
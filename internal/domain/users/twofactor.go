@@ -0,0 +1,209 @@
+package auth
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+
+	"question-answer/pkg/totp"
+)
+
+var (
+	ErrOTPRequired        = errors.New("one-time code required")
+	ErrInvalidOTP         = errors.New("invalid one-time code")
+	ErrTOTPAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTOTPNotEnrolled    = errors.New("two-factor authentication is not enrolled")
+	ErrTOTPNotEnabled     = errors.New("two-factor authentication is not enabled")
+)
+
+const (
+	recoveryCodeCount  = 10
+	recoveryCodeLength = 10
+	// recoveryCodeAlphabet has 32 symbols without look-alikes, so a random
+	// byte maps onto it without bias.
+	recoveryCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	// totpSkew is how many 30s steps of clock drift are tolerated.
+	totpSkew = 1
+)
+
+type TOTPEnrollment struct {
+	Secret string
+	URI    string
+}
+
+// EnrollTOTP generates a new shared secret for the user. It only takes effect
+// once ConfirmTOTP proves the authenticator app produces matching codes.
+func (s *service) EnrollTOTP(p Principal) (*TOTPEnrollment, error) {
+	const op = "auth.service.EnrollTOTP"
+
+	u, err := s.storage.GetUserByID(p.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if u.TOTPEnabled {
+		return nil, fmt.Errorf("%s: %w", op, ErrTOTPAlreadyEnabled)
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.storage.SetTOTPSecret(u.ID, secret); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &TOTPEnrollment{
+		Secret: secret,
+		URI:    totp.URI(s.cfg.TOTPIssuer, u.Username, secret),
+	}, nil
+}
+
+// ConfirmTOTP enables two-factor authentication after checking a code from
+// the freshly enrolled secret, and returns one-time recovery codes. The
+// codes are stored hashed and shown only here.
+func (s *service) ConfirmTOTP(p Principal, code string) ([]string, error) {
+	const op = "auth.service.ConfirmTOTP"
+
+	u, err := s.storage.GetUserByID(p.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if u.TOTPEnabled {
+		return nil, fmt.Errorf("%s: %w", op, ErrTOTPAlreadyEnabled)
+	}
+	if u.TOTPSecret == "" {
+		return nil, fmt.Errorf("%s: %w", op, ErrTOTPNotEnrolled)
+	}
+
+	if err := s.checkTOTP(u, code); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.storage.EnableTOTP(u.ID, hashes); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return codes, nil
+}
+
+// DisableTOTP turns two-factor authentication off. It requires a current
+// code or an unused recovery code.
+func (s *service) DisableTOTP(p Principal, code, recoveryCode string) error {
+	const op = "auth.service.DisableTOTP"
+
+	u, err := s.storage.GetUserByID(p.UserID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !u.TOTPEnabled {
+		return fmt.Errorf("%s: %w", op, ErrTOTPNotEnabled)
+	}
+
+	if err := s.checkSecondFactor(u, code, recoveryCode); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.storage.DisableTOTP(u.ID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// checkSecondFactor accepts either a TOTP code or a recovery code.
+func (s *service) checkSecondFactor(u *User, code, recoveryCode string) error {
+	switch {
+	case code != "":
+		return s.checkTOTP(u, code)
+	case recoveryCode != "":
+		used, err := s.storage.UseRecoveryCode(u.ID, hashToken(normalizeRecoveryCode(recoveryCode)), s.now())
+		if err != nil {
+			return err
+		}
+		if !used {
+			return ErrInvalidOTP
+		}
+		return nil
+	default:
+		return ErrOTPRequired
+	}
+}
+
+// checkTOTP verifies code and records its time step, so an intercepted code
+// cannot be replayed within its validity window.
+func (s *service) checkTOTP(u *User, code string) error {
+	step, ok := totp.Verify(u.TOTPSecret, strings.TrimSpace(code), s.now(), totpSkew)
+	if !ok {
+		return ErrInvalidOTP
+	}
+
+	fresh, err := s.storage.AdvanceTOTPStep(u.ID, step)
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return ErrInvalidOTP
+	}
+
+	return nil
+}
+
+// requiresTOTPSetup reports whether u holds a privileged role that must be
+// protected by a second factor but has not enabled one yet.
+func (s *service) requiresTOTPSetup(u *User) bool {
+	if !s.cfg.Require2FAForPrivileged || u.TOTPEnabled {
+		return false
+	}
+	for _, r := range u.Roles {
+		if r.Privileged() {
+			return true
+		}
+	}
+	return false
+}
+
+// effectiveRoles drops privileged roles while their mandatory second factor
+// is missing, leaving the user a plain member until they enroll.
+func (s *service) effectiveRoles(u *User) []Role {
+	if !s.requiresTOTPSetup(u) {
+		return u.Roles
+	}
+	roles := make([]Role, 0, len(u.Roles))
+	for _, r := range u.Roles {
+		if !r.Privileged() {
+			roles = append(roles, r)
+		}
+	}
+	return roles
+}
+
+func newRecoveryCodes() (codes, hashes []string, err error) {
+	codes = make([]string, recoveryCodeCount)
+	hashes = make([]string, recoveryCodeCount)
+
+	buf := make([]byte, recoveryCodeLength)
+	for i := range codes {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, fmt.Errorf("auth.newRecoveryCodes: %w", err)
+		}
+		for j, b := range buf {
+			buf[j] = recoveryCodeAlphabet[int(b)%len(recoveryCodeAlphabet)]
+		}
+		code := string(buf)
+		codes[i] = code[:5] + "-" + code[5:]
+		hashes[i] = hashToken(code)
+	}
+
+	return codes, hashes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
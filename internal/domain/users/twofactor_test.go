@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"question-answer/pkg/totp"
+)
+
+type totpStorage struct {
+	*sessionStorage
+	user     User
+	roles    []Role
+	lastStep int64
+	recovery map[string]bool
+}
+
+func newTOTPStorage(t *testing.T, roles ...Role) *totpStorage {
+	hash, err := bcrypt.GenerateFromPassword([]byte("s3cret-pass"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	return &totpStorage{
+		sessionStorage: newSessionStorage(),
+		user:           User{ID: 5, Username: "mod", PasswordHash: string(hash)},
+		roles:          roles,
+		recovery:       map[string]bool{},
+	}
+}
+
+func (m *totpStorage) GetUserByID(uint64) (*User, error) {
+	u := m.user
+	return &u, nil
+}
+
+func (m *totpStorage) GetUserByUsername(string) (*User, error) {
+	u := m.user
+	return &u, nil
+}
+
+func (m *totpStorage) GetUserRoles(uint64) ([]Role, error) {
+	return append([]Role(nil), m.roles...), nil
+}
+
+func (m *totpStorage) SetTOTPSecret(_ uint64, secret string) error {
+	m.user.TOTPSecret = secret
+	return nil
+}
+
+func (m *totpStorage) EnableTOTP(_ uint64, hashes []string) error {
+	m.user.TOTPEnabled = true
+	for _, h := range hashes {
+		m.recovery[h] = true
+	}
+	return nil
+}
+
+func (m *totpStorage) AdvanceTOTPStep(_ uint64, step int64) (bool, error) {
+	if step <= m.lastStep {
+		return false, nil
+	}
+	m.lastStep = step
+	return true, nil
+}
+
+func (m *totpStorage) UseRecoveryCode(_ uint64, hash string, _ time.Time) (bool, error) {
+	if !m.recovery[hash] {
+		return false, nil
+	}
+	m.recovery[hash] = false
+	return true, nil
+}
+
+type principalTokens struct{ last Principal }
+
+func (pt *principalTokens) Issue(p Principal) (string, time.Time, error) {
+	pt.last = p
+	return "access", time.Now().Add(time.Minute), nil
+}
+
+func (pt *principalTokens) Parse(string) (*Principal, error) { return nil, ErrInvalidToken }
+
+func TestTOTPLogin(t *testing.T) {
+	st := newTOTPStorage(t, RoleModerator)
+	tokens := &principalTokens{}
+	svc := NewService(st, tokens, nil, Config{
+		SessionTTL:              time.Hour,
+		TOTPIssuer:              "question-answer",
+		Require2FAForPrivileged: true,
+	}).(*service)
+
+	// Without a second factor the moderator logs in as a plain member.
+	_, issued, err := svc.Login(LoginInput{Username: "mod", Password: "s3cret-pass"})
+	require.NoError(t, err)
+	require.True(t, issued.TwoFactorSetupRequired)
+	require.False(t, tokens.last.HasPermission(PermModerateContent))
+
+	p := Principal{UserID: st.user.ID}
+	enrollment, err := svc.EnrollTOTP(p)
+	require.NoError(t, err)
+	require.Contains(t, enrollment.URI, "otpauth://totp/question-answer:mod?")
+
+	code, err := totp.Code(enrollment.Secret, totp.Step(time.Now()))
+	require.NoError(t, err)
+
+	recovery, err := svc.ConfirmTOTP(p, code)
+	require.NoError(t, err)
+	require.Len(t, recovery, recoveryCodeCount)
+
+	_, _, err = svc.Login(LoginInput{Username: "mod", Password: "s3cret-pass"})
+	require.ErrorIs(t, err, ErrOTPRequired)
+
+	// The code used for confirmation cannot be replayed.
+	_, _, err = svc.Login(LoginInput{Username: "mod", Password: "s3cret-pass", OTP: code})
+	require.ErrorIs(t, err, ErrInvalidOTP)
+
+	svc.now = func() time.Time { return time.Now().Add(totp.Period) }
+	next, err := totp.Code(enrollment.Secret, totp.Step(svc.now()))
+	require.NoError(t, err)
+
+	_, issued, err = svc.Login(LoginInput{Username: "mod", Password: "s3cret-pass", OTP: next})
+	require.NoError(t, err)
+	require.False(t, issued.TwoFactorSetupRequired)
+	require.True(t, tokens.last.HasPermission(PermModerateContent))
+
+	// Recovery codes work once, with or without the dash.
+	_, _, err = svc.Login(LoginInput{Username: "mod", Password: "s3cret-pass", RecoveryCode: recovery[0]})
+	require.NoError(t, err)
+	_, _, err = svc.Login(LoginInput{Username: "mod", Password: "s3cret-pass", RecoveryCode: normalizeRecoveryCode(recovery[0])})
+	require.ErrorIs(t, err, ErrInvalidOTP)
+}
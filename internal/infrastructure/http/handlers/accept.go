@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"question-answer/internal/domain/qa"
+	"question-answer/internal/infrastructure/http/middleware"
+	"question-answer/pkg/sl_logger/sl"
+)
+
+// POST /questions/{questionID}/accept/{answerID}
+func NewAcceptAnswerHandler(log *slog.Logger, svc qa.Service, questionIDStr, answerIDStr string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.question.acceptAnswer"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		questionID, err := strconv.ParseUint(questionIDStr, 10, 64)
+		if err != nil {
+			log.Error("failed to convert string", sl.Err(err))
+			authResponseErr(w, http.StatusBadRequest, "invalid question id")
+			return
+		}
+		answerID, err := strconv.ParseUint(answerIDStr, 10, 64)
+		if err != nil {
+			log.Error("failed to convert string", sl.Err(err))
+			authResponseErr(w, http.StatusBadRequest, "invalid answer id")
+			return
+		}
+
+		question, err := svc.AcceptAnswer(questionID, answerID, actorFromRequest(r))
+		if err != nil {
+			log.Error("failed to accept answer", sl.Err(err))
+			writeQAError(w, err, "failed to accept answer")
+			return
+		}
+
+		log.Info("answer accepted",
+			slog.Uint64("question_id", questionID),
+			slog.Uint64("answer_id", answerID),
+		)
+
+		addQuestionResponseOK(w, *question)
+	}
+}
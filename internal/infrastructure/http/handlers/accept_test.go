@@ -0,0 +1,110 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"question-answer/internal/domain/qa"
+	auth "question-answer/internal/domain/users"
+	"question-answer/internal/infrastructure/http/handlers"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/handlers/mocks"
+	"question-answer/internal/infrastructure/http/middleware"
+	slogdiscard "question-answer/pkg/sl_logger/slog_discard"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcceptAnswerHandler(t *testing.T) {
+	asker := &auth.Principal{UserID: 4, Username: "asker"}
+
+	cases := []struct {
+		name           string
+		answerID       string
+		callService    bool
+		mockReturnQ    *qa.Question
+		mockReturnErr  error
+		expectedStatus int
+	}{
+		{
+			name:           "Success",
+			answerID:       "8",
+			callService:    true,
+			mockReturnQ:    &qa.Question{ID: 2, UserID: 4, Title: "why?", AcceptedAnswerID: 8},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid answer id",
+			answerID:       "eight",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Not the asker",
+			answerID:       "8",
+			callService:    true,
+			mockReturnErr:  qa.ErrForbidden,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Answer to another question",
+			answerID:       "8",
+			callService:    true,
+			mockReturnErr:  fmt.Errorf("accept: %w", qa.ErrAnswerMismatch),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svcMock := mocks.NewService(t)
+
+			if tc.callService {
+				svcMock.On("AcceptAnswer", uint64(2), uint64(8), qa.Actor{UserID: asker.UserID}).
+					Return(tc.mockReturnQ, tc.mockReturnErr).
+					Once()
+			}
+
+			handler := handlers.NewAcceptAnswerHandler(slogdiscard.NewDiscardLogger(), svcMock, "2", tc.answerID)
+
+			req := httptest.NewRequest(http.MethodPost, "/questions/2/accept/"+tc.answerID, nil)
+			req = req.WithContext(middleware.WithPrincipal(req.Context(), asker))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expectedStatus, rr.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var resp dto.AddQuestionResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				require.Equal(t, uint64(8), resp.AcceptedAnswerID)
+			}
+
+			svcMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetQuestionHandlerAnsweredFilter(t *testing.T) {
+	svcMock := mocks.NewService(t)
+
+	answered := false
+	svcMock.On("GetAllQuestions", qa.QuestionFilter{Answered: &answered}, qa.PageRequest{}).
+		Return(&qa.QuestionPage{Questions: []qa.Question{{ID: 1, Title: "open question"}}}, nil).
+		Once()
+
+	handler := handlers.NewGetQuestionHandler(slogdiscard.NewDiscardLogger(), svcMock)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/questions?answered=false", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/questions?answered=maybe", nil))
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+
+	svcMock.AssertExpectations(t)
+}
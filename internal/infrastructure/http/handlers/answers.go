@@ -58,17 +58,19 @@ func NewAddAnswerHandler(log *slog.Logger, svc qa.Service, strID string) http.Ha
 		}
 
 		reqAnswer := qa.Answer{
-			UserID:     1,
 			Text:       req.Text,
 			QuestionID: uint64(questID),
 		}
+		if p, ok := middleware.GetPrincipal(r); ok {
+			reqAnswer.UserID = p.UserID
+		}
 
 		answerID, err := svc.CreateAnswer(reqAnswer)
 		if err != nil {
 			log.Error("failed to add Answer",
 				sl.Err(err),
 			)
-			addQuestionResponseErr(w, transport.ErrFailedToDecodeReqBody.Error())
+			writeQAError(w, err, transport.ErrFailedToDecodeReqBody.Error())
 			return
 		}
 
@@ -138,12 +140,12 @@ func NewDeleteAnswerHandler(log *slog.Logger, svc qa.Service, strID string) http
 			return
 		}
 
-		err = svc.DeleteAnswer(uint64(answerID))
+		err = svc.DeleteAnswer(uint64(answerID), actorFromRequest(r))
 		if err != nil {
-			log.Error("failed to get Answer",
+			log.Error("failed to delete Answer",
 				sl.Err(err),
 			)
-			deleteQuestionResponseErr(w, transport.ErrFailedToDecodeReqBody.Error())
+			writeQAError(w, err, "failed to delete answer")
 			return
 		}
 
@@ -179,10 +181,7 @@ func getAnswerResponseErr(w http.ResponseWriter, e string) {
 func getAnswerResponseOK(w http.ResponseWriter, ans qa.Answer) {
 	r := dto.GetAnswerResponse{
 		ValidationResponse: validators.OK(),
-		AnswerResponse: dto.AnswerResponse{
-			Text:      ans.Text,
-			CreatedAt: ans.CreatedAt,
-		},
+		AnswerResponse:     toAnswerResponse(ans),
 	}
 	transport.WriteJSON(w, http.StatusOK, r)
 }
@@ -200,3 +199,27 @@ func deleteAnswerResponseOK(w http.ResponseWriter) {
 	}
 	transport.WriteJSON(w, http.StatusOK, r)
 }
+
+func toAnswerResponse(a qa.Answer) dto.AnswerResponse {
+	return dto.AnswerResponse{
+		ID:               a.ID,
+		QuestionID:       a.QuestionID,
+		UserID:           a.UserID,
+		Text:             a.Text,
+		CreatedAt:        a.CreatedAt,
+		Score:            a.Score,
+		MyVote:           a.ViewerVote.String(),
+		AuthorReputation: a.AuthorReputation,
+		Accepted:         a.Accepted,
+		Comments:         toCommentResponses(a.Comments),
+		DeletedAt:        a.DeletedAt,
+	}
+}
+
+func toAnswerResponses(a []qa.Answer) []dto.AnswerResponse {
+	answers := make([]dto.AnswerResponse, 0, len(a))
+	for _, v := range a {
+		answers = append(answers, toAnswerResponse(v))
+	}
+	return answers
+}
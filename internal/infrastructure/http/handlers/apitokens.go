@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	auth "question-answer/internal/domain/users"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/middleware"
+	"question-answer/internal/infrastructure/http/transport"
+	"question-answer/pkg/sl_logger/sl"
+	validateResp "question-answer/pkg/validator"
+)
+
+// POST /auth/tokens
+func NewCreateAPITokenHandler(log *slog.Logger, svc auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.apitokens.create"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		var req dto.CreateAPITokenRequest
+		if !decodeAuthRequest(log, w, r, &req) {
+			return
+		}
+
+		scopes := make([]auth.Scope, 0, len(req.Scopes))
+		for _, s := range req.Scopes {
+			scope, err := auth.ParseScope(s)
+			if err != nil {
+				authResponseErr(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			scopes = append(scopes, scope)
+		}
+
+		p, _ := middleware.GetPrincipal(r)
+
+		t, raw, err := svc.CreateAPIToken(*p, req.Name, scopes, req.ExpiresAt)
+		if err != nil {
+			log.Error("failed to create api token", sl.Err(err))
+			writeAPITokenError(w, err)
+			return
+		}
+
+		log.Info("api token created",
+			slog.Uint64("user_id", p.UserID),
+			slog.Uint64("token_id", t.ID),
+		)
+
+		resp := toAPITokenResponse(*t)
+		transport.WriteJSON(w, http.StatusCreated, dto.CreateAPITokenResponse{
+			ValidationResponse: validateResp.OK(),
+			Token:              raw,
+			APIToken:           &resp,
+		})
+	}
+}
+
+// GET /auth/tokens
+func NewListAPITokensHandler(log *slog.Logger, svc auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.apitokens.list"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		p, _ := middleware.GetPrincipal(r)
+
+		tokens, err := svc.ListAPITokens(*p)
+		if err != nil {
+			log.Error("failed to list api tokens", sl.Err(err))
+			authResponseErr(w, http.StatusInternalServerError, "failed to list api tokens")
+			return
+		}
+
+		resp := make([]dto.APITokenResponse, 0, len(tokens))
+		for _, t := range tokens {
+			resp = append(resp, toAPITokenResponse(t))
+		}
+
+		transport.WriteJSON(w, http.StatusOK, dto.ListAPITokensResponse{
+			ValidationResponse: validateResp.OK(),
+			APITokens:          resp,
+		})
+	}
+}
+
+// DELETE /auth/tokens/{tokenID}
+func NewRevokeAPITokenHandler(log *slog.Logger, svc auth.Service, idStr string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.apitokens.revoke"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		id, err := strconv.ParseUint(idStr, 10, 64)
+		if err != nil {
+			log.Error("failed to convert string", sl.Err(err))
+			authResponseErr(w, http.StatusBadRequest, "invalid token id")
+			return
+		}
+
+		p, _ := middleware.GetPrincipal(r)
+
+		if err := svc.RevokeAPIToken(*p, id); err != nil {
+			log.Error("failed to revoke api token", sl.Err(err))
+			writeAPITokenError(w, err)
+			return
+		}
+
+		log.Info("api token revoked",
+			slog.Uint64("user_id", p.UserID),
+			slog.Uint64("token_id", id),
+		)
+
+		transport.WriteJSON(w, http.StatusOK, validateResp.OK())
+	}
+}
+
+func toAPITokenResponse(t auth.APIToken) dto.APITokenResponse {
+	scopes := make([]string, 0, len(t.Scopes))
+	for _, s := range t.Scopes {
+		scopes = append(scopes, string(s))
+	}
+	return dto.APITokenResponse{
+		ID:         t.ID,
+		Name:       t.Name,
+		Prefix:     t.Prefix,
+		Scopes:     scopes,
+		ExpiresAt:  t.ExpiresAt,
+		LastUsedAt: t.LastUsedAt,
+		CreatedAt:  t.CreatedAt,
+	}
+}
+
+func writeAPITokenError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, auth.ErrAPITokenNotFound):
+		authResponseErr(w, http.StatusNotFound, auth.ErrAPITokenNotFound.Error())
+	case errors.Is(err, auth.ErrInvalidExpiration):
+		authResponseErr(w, http.StatusBadRequest, auth.ErrInvalidExpiration.Error())
+	case errors.Is(err, auth.ErrSessionRequired):
+		authResponseErr(w, http.StatusForbidden, auth.ErrSessionRequired.Error())
+	default:
+		authResponseErr(w, http.StatusInternalServerError, "failed to manage api tokens")
+	}
+}
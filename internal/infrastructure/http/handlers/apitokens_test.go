@@ -0,0 +1,195 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	auth "question-answer/internal/domain/users"
+	"question-answer/internal/infrastructure/http/handlers"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/handlers/mocks"
+	"question-answer/internal/infrastructure/http/middleware"
+	slogdiscard "question-answer/pkg/sl_logger/slog_discard"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var tokenOwner = &auth.Principal{UserID: 4, Username: "gopher", Roles: []auth.Role{auth.RoleMember}, SessionID: "s1"}
+
+func TestCreateAPITokenHandler(t *testing.T) {
+	fixedTime := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name           string
+		reqBody        string
+		callService    bool
+		mockScopes     []auth.Scope
+		mockReturnErr  error
+		expectedStatus int
+	}{
+		{
+			name:           "Success",
+			reqBody:        `{"name": "ci", "scopes": ["questions:read", "answers:write"]}`,
+			callService:    true,
+			mockScopes:     []auth.Scope{auth.ScopeQuestionsRead, auth.ScopeAnswersWrite},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Comment scopes",
+			reqBody:        `{"name": "ci", "scopes": ["comments:read", "comments:write"]}`,
+			callService:    true,
+			mockScopes:     []auth.Scope{auth.ScopeCommentsRead, auth.ScopeCommentsWrite},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Unknown scope",
+			reqBody:        `{"name": "ci", "scopes": ["questions:delete"]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "No scopes",
+			reqBody:        `{"name": "ci", "scopes": []}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Expiry in the past",
+			reqBody:        `{"name": "ci", "scopes": ["questions:read"], "expires_at": "2020-01-01T00:00:00Z"}`,
+			callService:    true,
+			mockScopes:     []auth.Scope{auth.ScopeQuestionsRead},
+			mockReturnErr:  fmt.Errorf("create: %w", auth.ErrInvalidExpiration),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Created with an API token",
+			reqBody:        `{"name": "ci", "scopes": ["questions:read"]}`,
+			callService:    true,
+			mockScopes:     []auth.Scope{auth.ScopeQuestionsRead},
+			mockReturnErr:  auth.ErrSessionRequired,
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svcMock := mocks.NewAuthService(t)
+
+			if tc.callService {
+				var token *auth.APIToken
+				var raw string
+				if tc.mockReturnErr == nil {
+					token = &auth.APIToken{ID: 9, Name: "ci", Prefix: "qa_abcd", Scopes: tc.mockScopes, CreatedAt: fixedTime}
+					raw = "qa_abcdsecret"
+				}
+				svcMock.On("CreateAPIToken", *tokenOwner, "ci", tc.mockScopes, mock.Anything).
+					Return(token, raw, tc.mockReturnErr).
+					Once()
+			}
+
+			handler := handlers.NewCreateAPITokenHandler(slogdiscard.NewDiscardLogger(), svcMock)
+
+			req := httptest.NewRequest(http.MethodPost, "/auth/tokens", strings.NewReader(tc.reqBody))
+			req = req.WithContext(middleware.WithPrincipal(req.Context(), tokenOwner))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expectedStatus, rr.Code)
+
+			if tc.expectedStatus == http.StatusCreated {
+				var resp dto.CreateAPITokenResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				require.Equal(t, "qa_abcdsecret", resp.Token)
+				require.Len(t, resp.APIToken.Scopes, len(tc.mockScopes))
+			}
+
+			svcMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListAPITokensHandler(t *testing.T) {
+	svcMock := mocks.NewAuthService(t)
+
+	svcMock.On("ListAPITokens", *tokenOwner).Return([]auth.APIToken{
+		{ID: 9, Name: "ci", Prefix: "qa_abcd", Scopes: []auth.Scope{auth.ScopeQuestionsRead}},
+	}, nil).Once()
+	svcMock.On("ListAPITokens", *tokenOwner).Return(nil, errors.New("db down")).Once()
+
+	handler := handlers.NewListAPITokensHandler(slogdiscard.NewDiscardLogger(), svcMock)
+
+	req := httptest.NewRequest(http.MethodGet, "/auth/tokens", nil)
+	req = req.WithContext(middleware.WithPrincipal(req.Context(), tokenOwner))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp dto.ListAPITokensResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Len(t, resp.APITokens, 1)
+	require.Equal(t, "qa_abcd", resp.APITokens[0].Prefix)
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestRevokeAPITokenHandler(t *testing.T) {
+	cases := []struct {
+		name           string
+		tokenID        string
+		callService    bool
+		mockReturnErr  error
+		expectedStatus int
+	}{
+		{
+			name:           "Success",
+			tokenID:        "9",
+			callService:    true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid token id",
+			tokenID:        "nine",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Another user's token",
+			tokenID:        "9",
+			callService:    true,
+			mockReturnErr:  fmt.Errorf("storage: %w", auth.ErrAPITokenNotFound),
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svcMock := mocks.NewAuthService(t)
+
+			if tc.callService {
+				svcMock.On("RevokeAPIToken", *tokenOwner, uint64(9)).Return(tc.mockReturnErr).Once()
+			}
+
+			handler := handlers.NewRevokeAPITokenHandler(slogdiscard.NewDiscardLogger(), svcMock, tc.tokenID)
+
+			req := httptest.NewRequest(http.MethodDelete, "/auth/tokens/"+tc.tokenID, nil)
+			req = req.WithContext(middleware.WithPrincipal(req.Context(), tokenOwner))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expectedStatus, rr.Code)
+
+			svcMock.AssertExpectations(t)
+		})
+	}
+}
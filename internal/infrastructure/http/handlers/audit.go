@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	auth "question-answer/internal/domain/users"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/middleware"
+	"question-answer/internal/infrastructure/http/transport"
+	"question-answer/pkg/sl_logger/sl"
+	validateResp "question-answer/pkg/validator"
+)
+
+const (
+	defaultAuthEventsLimit = 50
+	maxAuthEventsLimit     = 500
+)
+
+// GET /admin/auth-events?user_id=&username=&ip=&type=&limit=
+func NewListAuthEventsHandler(log *slog.Logger, svc auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.audit.list"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		q := r.URL.Query()
+		filter := auth.AuthEventFilter{
+			Username: q.Get("username"),
+			IP:       q.Get("ip"),
+			Type:     auth.EventType(q.Get("type")),
+			Limit:    defaultAuthEventsLimit,
+		}
+
+		if v := q.Get("user_id"); v != "" {
+			id, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				authResponseErr(w, http.StatusBadRequest, "invalid user id")
+				return
+			}
+			filter.UserID = id
+		}
+		if v := q.Get("limit"); v != "" {
+			limit, err := strconv.Atoi(v)
+			if err != nil || limit < 1 {
+				authResponseErr(w, http.StatusBadRequest, "invalid limit")
+				return
+			}
+			filter.Limit = min(limit, maxAuthEventsLimit)
+		}
+
+		events, err := svc.ListAuthEvents(filter)
+		if err != nil {
+			log.Error("failed to list auth events", sl.Err(err))
+			authResponseErr(w, http.StatusInternalServerError, "failed to list auth events")
+			return
+		}
+
+		resp := make([]dto.AuthEventResponse, 0, len(events))
+		for _, e := range events {
+			resp = append(resp, dto.AuthEventResponse{
+				ID:        e.ID,
+				UserID:    e.UserID,
+				Username:  e.Username,
+				Type:      string(e.Type),
+				IP:        e.IP,
+				UserAgent: e.UserAgent,
+				Detail:    e.Detail,
+				CreatedAt: e.CreatedAt,
+			})
+		}
+
+		transport.WriteJSON(w, http.StatusOK, dto.ListAuthEventsResponse{
+			ValidationResponse: validateResp.OK(),
+			Events:             resp,
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	auth "question-answer/internal/domain/users"
+	"question-answer/internal/infrastructure/http/handlers"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/handlers/mocks"
+	slogdiscard "question-answer/pkg/sl_logger/slog_discard"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAuthEventsHandler(t *testing.T) {
+	fixedTime := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name           string
+		query          string
+		callService    bool
+		mockFilter     auth.AuthEventFilter
+		mockReturnErr  error
+		expectedStatus int
+	}{
+		{
+			name:           "Default limit",
+			query:          "",
+			callService:    true,
+			mockFilter:     auth.AuthEventFilter{Limit: 50},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "All filters",
+			query:          "?user_id=3&username=gopher&ip=192.0.2.1&type=login_failed&limit=10",
+			callService:    true,
+			mockFilter:     auth.AuthEventFilter{UserID: 3, Username: "gopher", IP: "192.0.2.1", Type: auth.EventLoginFailed, Limit: 10},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Limit capped",
+			query:          "?limit=100000",
+			callService:    true,
+			mockFilter:     auth.AuthEventFilter{Limit: 500},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid user id",
+			query:          "?user_id=gopher",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Zero limit",
+			query:          "?limit=0",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid limit",
+			query:          "?limit=many",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Storage failure",
+			query:          "",
+			callService:    true,
+			mockFilter:     auth.AuthEventFilter{Limit: 50},
+			mockReturnErr:  errors.New("db down"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svcMock := mocks.NewAuthService(t)
+
+			if tc.callService {
+				var events []auth.AuthEvent
+				if tc.mockReturnErr == nil {
+					events = []auth.AuthEvent{{
+						ID:        1,
+						UserID:    3,
+						Username:  "gopher",
+						Type:      auth.EventLoginFailed,
+						IP:        "192.0.2.1",
+						Detail:    "wrong password",
+						CreatedAt: fixedTime,
+					}}
+				}
+				svcMock.On("ListAuthEvents", tc.mockFilter).Return(events, tc.mockReturnErr).Once()
+			}
+
+			handler := handlers.NewListAuthEventsHandler(slogdiscard.NewDiscardLogger(), svcMock)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/admin/auth-events"+tc.query, nil))
+
+			require.Equal(t, tc.expectedStatus, rr.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var resp dto.ListAuthEventsResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				require.Len(t, resp.Events, 1)
+				require.Equal(t, "login_failed", resp.Events[0].Type)
+				require.Equal(t, "wrong password", resp.Events[0].Detail)
+			}
+
+			svcMock.AssertExpectations(t)
+		})
+	}
+}
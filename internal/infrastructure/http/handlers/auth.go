@@ -0,0 +1,359 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+
+	auth "question-answer/internal/domain/users"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/middleware"
+	"question-answer/internal/infrastructure/http/transport"
+	"question-answer/pkg/sl_logger/sl"
+	validateResp "question-answer/pkg/validator"
+
+	"github.com/go-playground/validator"
+)
+
+// POST /auth/register
+func NewRegisterHandler(log *slog.Logger, svc auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.auth.register"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		var req dto.RegisterRequest
+		if !decodeAuthRequest(log, w, r, &req) {
+			return
+		}
+
+		user, err := svc.Register(req.Username, req.Email, req.Password)
+		if errors.Is(err, auth.ErrUserExists) {
+			log.Info("username conflict", slog.String("username", req.Username))
+			authResponseErr(w, http.StatusConflict, auth.ErrUserExists.Error())
+			return
+		}
+		if errors.Is(err, auth.ErrEmailExists) {
+			log.Info("email conflict")
+			authResponseErr(w, http.StatusConflict, auth.ErrEmailExists.Error())
+			return
+		}
+		if err != nil {
+			log.Error("failed to register user", sl.Err(err))
+			authResponseErr(w, http.StatusInternalServerError, "failed to register user")
+			return
+		}
+
+		log.Info("user registered", slog.Uint64("user_id", user.ID))
+
+		transport.WriteJSON(w, http.StatusCreated, dto.RegisterResponse{
+			ValidationResponse: validateResp.OK(),
+			User:               toUserResponse(user),
+		})
+	}
+}
+
+// POST /auth/login
+func NewLoginHandler(log *slog.Logger, svc auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.auth.login"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		var req dto.LoginRequest
+		if !decodeAuthRequest(log, w, r, &req) {
+			return
+		}
+
+		user, tokens, err := svc.Login(auth.LoginInput{
+			Username:     req.Username,
+			Password:     req.Password,
+			OTP:          req.OTP,
+			RecoveryCode: req.RecoveryCode,
+			Client:       clientFromRequest(r),
+		})
+		if err != nil {
+			writeLoginError(log.With(slog.String("username", req.Username)), w, err)
+			return
+		}
+
+		log.Info("user logged in", slog.Uint64("user_id", user.ID))
+
+		transport.WriteJSON(w, http.StatusOK, dto.LoginResponse{
+			ValidationResponse:     validateResp.OK(),
+			User:                   toUserResponse(user),
+			Token:                  toTokenResponse(tokens),
+			TwoFactorSetupRequired: tokens.TwoFactorSetupRequired,
+		})
+	}
+}
+
+// writeLoginError writes the response for an error returned by a login.
+func writeLoginError(log *slog.Logger, w http.ResponseWriter, err error) {
+	var locked *auth.LockedError
+
+	switch {
+	case errors.As(err, &locked):
+		log.Warn("login throttled", slog.Duration("retry_after", locked.RetryAfter))
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+		authResponseErr(w, http.StatusTooManyRequests, auth.ErrTooManyAttempts.Error())
+	case errors.Is(err, auth.ErrInvalidCredentials):
+		log.Info("login rejected")
+		authResponseErr(w, http.StatusUnauthorized, auth.ErrInvalidCredentials.Error())
+	case errors.Is(err, auth.ErrOTPRequired):
+		transport.WriteJSON(w, http.StatusUnauthorized, dto.LoginResponse{
+			ValidationResponse: validateResp.Error(auth.ErrOTPRequired.Error()),
+			OTPRequired:        true,
+		})
+	case errors.Is(err, auth.ErrInvalidOTP):
+		log.Info("one-time code rejected")
+		authResponseErr(w, http.StatusUnauthorized, auth.ErrInvalidOTP.Error())
+	default:
+		log.Error("failed to login", sl.Err(err))
+		authResponseErr(w, http.StatusInternalServerError, "failed to login")
+	}
+}
+
+// POST /auth/refresh
+func NewRefreshHandler(log *slog.Logger, svc auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.auth.refresh"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		var req dto.RefreshRequest
+		if !decodeAuthRequest(log, w, r, &req) {
+			return
+		}
+
+		tokens, err := svc.Refresh(req.RefreshToken)
+		switch {
+		case errors.Is(err, auth.ErrRefreshTokenReused):
+			log.Warn("refresh token reuse, session revoked", sl.Err(err))
+			authResponseErr(w, http.StatusUnauthorized, auth.ErrRefreshTokenReused.Error())
+			return
+		case errors.Is(err, auth.ErrInvalidRefreshToken),
+			errors.Is(err, auth.ErrSessionRevoked),
+			errors.Is(err, auth.ErrSessionNotFound):
+			log.Info("refresh rejected", sl.Err(err))
+			authResponseErr(w, http.StatusUnauthorized, auth.ErrInvalidRefreshToken.Error())
+			return
+		case err != nil:
+			log.Error("failed to refresh", sl.Err(err))
+			authResponseErr(w, http.StatusInternalServerError, "failed to refresh")
+			return
+		}
+
+		transport.WriteJSON(w, http.StatusOK, dto.RefreshResponse{
+			ValidationResponse: validateResp.OK(),
+			Token:              toTokenResponse(tokens),
+		})
+	}
+}
+
+// POST /auth/logout and /auth/logout/all
+func NewLogoutHandler(log *slog.Logger, svc auth.Service, allDevices bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.auth.logout"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		p, _ := middleware.GetPrincipal(r)
+
+		var err error
+		if allDevices {
+			err = svc.LogoutAll(*p, clientFromRequest(r))
+		} else {
+			err = svc.Logout(*p, clientFromRequest(r))
+		}
+		if err != nil {
+			log.Error("failed to logout", sl.Err(err))
+			authResponseErr(w, http.StatusInternalServerError, "failed to logout")
+			return
+		}
+
+		log.Info("user logged out",
+			slog.Uint64("user_id", p.UserID),
+			slog.Bool("all_devices", allDevices),
+		)
+
+		transport.WriteJSON(w, http.StatusOK, validateResp.OK())
+	}
+}
+
+// POST /auth/password/forgot
+func NewForgotPasswordHandler(log *slog.Logger, svc auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.auth.forgotPassword"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		var req dto.ForgotPasswordRequest
+		if !decodeAuthRequest(log, w, r, &req) {
+			return
+		}
+
+		// Failures are only logged: they happen for known addresses alone,
+		// so reporting them would tell which addresses have accounts.
+		if err := svc.ForgotPassword(req.Email); err != nil {
+			log.Error("failed to start password reset", sl.Err(err))
+		}
+
+		// The same answer is given whether or not the address is known.
+		transport.WriteJSON(w, http.StatusAccepted, validateResp.OK())
+	}
+}
+
+// POST /auth/password/reset
+func NewResetPasswordHandler(log *slog.Logger, svc auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.auth.resetPassword"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		var req dto.ResetPasswordRequest
+		if !decodeAuthRequest(log, w, r, &req) {
+			return
+		}
+
+		err := svc.ResetPassword(req.Token, req.Password, clientFromRequest(r))
+		if errors.Is(err, auth.ErrInvalidResetToken) {
+			log.Info("reset rejected", sl.Err(err))
+			authResponseErr(w, http.StatusBadRequest, auth.ErrInvalidResetToken.Error())
+			return
+		}
+		if err != nil {
+			log.Error("failed to reset password", sl.Err(err))
+			authResponseErr(w, http.StatusInternalServerError, "failed to reset password")
+			return
+		}
+
+		log.Info("password reset")
+
+		transport.WriteJSON(w, http.StatusOK, validateResp.OK())
+	}
+}
+
+// decodeAuthRequest decodes and validates the JSON body into req. It writes
+// the error response itself and reports whether the handler may continue.
+func decodeAuthRequest(log *slog.Logger, w http.ResponseWriter, r *http.Request, req any) bool {
+	err := json.NewDecoder(r.Body).Decode(req)
+	if errors.Is(err, io.EOF) {
+		log.Error("bad request",
+			slog.String("type", transport.ErrEmptyReqBody.Error()),
+			sl.Err(err),
+		)
+		authResponseErr(w, http.StatusBadRequest, transport.ErrEmptyReqBody.Error())
+		return false
+	}
+	if err != nil {
+		log.Error("bad request",
+			slog.String("type", transport.ErrFailedToDecodeReqBody.Error()),
+			sl.Err(err),
+		)
+		authResponseErr(w, http.StatusBadRequest, transport.ErrFailedToDecodeReqBody.Error())
+		return false
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		validateErr := err.(validator.ValidationErrors)
+		log.Error("invalid request", sl.Err(err))
+		_ = transport.WriteJSON(w, http.StatusBadRequest, validateResp.ValidationError(validateErr))
+		return false
+	}
+
+	return true
+}
+
+func toUserResponse(u *auth.User) *dto.UserResponse {
+	return &dto.UserResponse{
+		ID:               u.ID,
+		Username:         u.Username,
+		Roles:            roleNames(u.Roles),
+		TwoFactorEnabled: u.TOTPEnabled,
+		CreatedAt:        u.CreatedAt,
+	}
+}
+
+func roleNames(roles []auth.Role) []string {
+	if len(roles) == 0 {
+		return nil
+	}
+	names := make([]string, len(roles))
+	for i, r := range roles {
+		names[i] = string(r)
+	}
+	return names
+}
+
+func toTokenResponse(t *auth.Tokens) *dto.TokenResponse {
+	return &dto.TokenResponse{
+		AccessToken:      t.AccessToken,
+		TokenType:        "Bearer",
+		ExpiresAt:        t.ExpiresAt,
+		RefreshToken:     t.RefreshToken,
+		RefreshExpiresAt: t.RefreshExpiresAt,
+	}
+}
+
+// clientFromRequest describes the caller for the audit log. Only the
+// connection address is used: forwarding headers are client-controlled and
+// would let an attacker dodge the per-address lockout.
+func clientFromRequest(r *http.Request) auth.Client {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	return auth.Client{IP: ip, UserAgent: r.UserAgent()}
+}
+
+func authResponseErr(w http.ResponseWriter, status int, e string) {
+	transport.WriteJSON(w, status, validateResp.Error(e))
+}
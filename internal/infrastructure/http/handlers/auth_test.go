@@ -0,0 +1,155 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	auth "question-answer/internal/domain/users"
+	"question-answer/internal/infrastructure/http/handlers"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/handlers/mocks"
+	slogdiscard "question-answer/pkg/sl_logger/slog_discard"
+	validateresp "question-answer/pkg/validator"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterHandler(t *testing.T) {
+	fixedTime := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name                string
+		reqBody             string
+		callService         bool
+		mockReturnU         *auth.User
+		mockReturnErr       error
+		expectedStatus      int
+		expectedStatusField string
+	}{
+		{
+			name:        "Success",
+			reqBody:     `{"username": "gopher", "password": "s3cret-pass"}`,
+			callService: true,
+			mockReturnU: &auth.User{
+				ID:        2,
+				Username:  "gopher",
+				CreatedAt: fixedTime,
+			},
+			expectedStatus:      http.StatusCreated,
+			expectedStatusField: validateresp.StatusOK,
+		},
+		{
+			name:                "Short password",
+			reqBody:             `{"username": "gopher", "password": "123"}`,
+			expectedStatus:      http.StatusBadRequest,
+			expectedStatusField: validateresp.StatusError,
+		},
+		{
+			name:                "Duplicate username",
+			reqBody:             `{"username": "gopher", "password": "s3cret-pass"}`,
+			callService:         true,
+			mockReturnErr:       fmt.Errorf("storage: %w", auth.ErrUserExists),
+			expectedStatus:      http.StatusConflict,
+			expectedStatusField: validateresp.StatusError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svcMock := mocks.NewAuthService(t)
+
+			if tc.callService {
+				svcMock.On("Register", "gopher", "", "s3cret-pass").
+					Return(tc.mockReturnU, tc.mockReturnErr).
+					Once()
+			}
+
+			handler := handlers.NewRegisterHandler(slogdiscard.NewDiscardLogger(), svcMock)
+
+			req, err := http.NewRequest(http.MethodPost, "/auth/register", bytes.NewReader([]byte(tc.reqBody)))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expectedStatus, rr.Code)
+
+			var resp dto.RegisterResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.Equal(t, tc.expectedStatusField, resp.Status)
+
+			if tc.mockReturnU != nil {
+				require.NotNil(t, resp.User)
+				require.Equal(t, tc.mockReturnU.ID, resp.User.ID)
+				require.Equal(t, tc.mockReturnU.Username, resp.User.Username)
+			}
+
+			svcMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLoginHandlerThrottled(t *testing.T) {
+	svcMock := mocks.NewAuthService(t)
+
+	svcMock.On("Login", auth.LoginInput{
+		Username: "gopher",
+		Password: "s3cret-pass",
+		Client:   auth.Client{IP: "192.0.2.1", UserAgent: "curl/8"},
+	}).Return(nil, nil, fmt.Errorf("login: %w", &auth.LockedError{RetryAfter: 1500 * time.Millisecond})).Once()
+
+	handler := handlers.NewLoginHandler(slogdiscard.NewDiscardLogger(), svcMock)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/login",
+		bytes.NewReader([]byte(`{"username": "gopher", "password": "s3cret-pass"}`)))
+	req.RemoteAddr = "192.0.2.1:51234"
+	req.Header.Set("User-Agent", "curl/8")
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusTooManyRequests, rr.Code)
+	require.Equal(t, "2", rr.Header().Get("Retry-After"))
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestForgotPasswordHandler(t *testing.T) {
+	cases := []struct {
+		name          string
+		email         string
+		mockReturnErr error
+	}{
+		{
+			name:  "Known or unknown address",
+			email: "gopher@example.com",
+		},
+		{
+			name:          "Mail failure",
+			email:         "gopher@example.com",
+			mockReturnErr: fmt.Errorf("mail: %w", errors.New("connection refused")),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svcMock := mocks.NewAuthService(t)
+			svcMock.On("ForgotPassword", tc.email).Return(tc.mockReturnErr).Once()
+
+			handler := handlers.NewForgotPasswordHandler(slogdiscard.NewDiscardLogger(), svcMock)
+
+			body := `{"email": "` + tc.email + `"}`
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/auth/password/forgot", bytes.NewBufferString(body)))
+
+			require.Equal(t, http.StatusAccepted, rr.Code)
+			svcMock.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"question-answer/internal/domain/qa"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/middleware"
+	"question-answer/internal/infrastructure/http/transport"
+	"question-answer/pkg/sl_logger/sl"
+	validateResp "question-answer/pkg/validator"
+)
+
+// POST /questions/{questionID}/comments and /answers/{answerID}/comments
+func NewAddCommentHandler(log *slog.Logger, svc qa.Service, onAnswer bool, idStr string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.comments.add"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		id, err := strconv.ParseUint(idStr, 10, 64)
+		if err != nil {
+			log.Error("failed to convert string", sl.Err(err))
+			authResponseErr(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		var req dto.CommentRequest
+		if !decodeAuthRequest(log, w, r, &req) {
+			return
+		}
+
+		c := qa.Comment{
+			UserID: actorFromRequest(r).UserID,
+			Text:   req.Text,
+		}
+		if onAnswer {
+			c.AnswerID = id
+		} else {
+			c.QuestionID = id
+		}
+
+		comment, err := svc.AddComment(c)
+		if err != nil {
+			log.Error("failed to add comment", sl.Err(err))
+			writeQAError(w, err, "failed to add comment")
+			return
+		}
+
+		log.Info("comment added", slog.Uint64("comment_id", comment.ID))
+
+		transport.WriteJSON(w, http.StatusCreated, dto.AddCommentResponse{
+			ValidationResponse: validateResp.OK(),
+			Comment:            toCommentResponse(*comment),
+		})
+	}
+}
+
+// GET /questions/{questionID}/comments and /answers/{answerID}/comments
+func NewListCommentsHandler(log *slog.Logger, svc qa.Service, onAnswer bool, idStr string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.comments.list"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		id, err := strconv.ParseUint(idStr, 10, 64)
+		if err != nil {
+			log.Error("failed to convert string", sl.Err(err))
+			authResponseErr(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		var comments []qa.Comment
+		if onAnswer {
+			comments, err = svc.GetAnswerComments(id)
+		} else {
+			comments, err = svc.GetQuestionComments(id)
+		}
+		if err != nil {
+			log.Error("failed to list comments", sl.Err(err))
+			writeQAError(w, err, "failed to list comments")
+			return
+		}
+
+		data := toCommentResponses(comments)
+		if data == nil {
+			data = []dto.CommentResponse{}
+		}
+
+		transport.WriteJSON(w, http.StatusOK, dto.ListCommentsResponse{
+			ValidationResponse: validateResp.OK(),
+			Data:               data,
+		})
+	}
+}
+
+// DELETE /comments/{commentID}
+func NewDeleteCommentHandler(log *slog.Logger, svc qa.Service, idStr string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.comments.delete"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		id, err := strconv.ParseUint(idStr, 10, 64)
+		if err != nil {
+			log.Error("failed to convert string", sl.Err(err))
+			authResponseErr(w, http.StatusBadRequest, "invalid comment id")
+			return
+		}
+
+		if err := svc.DeleteComment(id, actorFromRequest(r)); err != nil {
+			log.Error("failed to delete comment", sl.Err(err))
+			writeQAError(w, err, "failed to delete comment")
+			return
+		}
+
+		log.Info("comment deleted", slog.Uint64("comment_id", id))
+
+		transport.WriteJSON(w, http.StatusOK, validateResp.OK())
+	}
+}
+
+func toCommentResponse(c qa.Comment) dto.CommentResponse {
+	return dto.CommentResponse{
+		ID:         c.ID,
+		QuestionID: c.QuestionID,
+		AnswerID:   c.AnswerID,
+		UserID:     c.UserID,
+		Text:       c.Text,
+		CreatedAt:  c.CreatedAt,
+	}
+}
+
+func toCommentResponses(c []qa.Comment) []dto.CommentResponse {
+	if len(c) == 0 {
+		return nil
+	}
+	comments := make([]dto.CommentResponse, len(c))
+	for i, v := range c {
+		comments[i] = toCommentResponse(v)
+	}
+	return comments
+}
@@ -0,0 +1,144 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"question-answer/internal/domain/qa"
+	auth "question-answer/internal/domain/users"
+	"question-answer/internal/infrastructure/http/handlers"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/handlers/mocks"
+	"question-answer/internal/infrastructure/http/middleware"
+	slogdiscard "question-answer/pkg/sl_logger/slog_discard"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddCommentHandler(t *testing.T) {
+	commenter := &auth.Principal{UserID: 6, Username: "commenter"}
+
+	cases := []struct {
+		name           string
+		onAnswer       bool
+		reqBody        string
+		callService    bool
+		expected       qa.Comment
+		mockReturnErr  error
+		expectedStatus int
+	}{
+		{
+			name:           "On question",
+			reqBody:        `{"text": "which version?"}`,
+			callService:    true,
+			expected:       qa.Comment{QuestionID: 3, UserID: 6, Text: "which version?"},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "On answer",
+			onAnswer:       true,
+			reqBody:        `{"text": "works for me"}`,
+			callService:    true,
+			expected:       qa.Comment{AnswerID: 3, UserID: 6, Text: "works for me"},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Too long",
+			reqBody:        fmt.Sprintf(`{"text": %q}`, strings.Repeat("x", qa.MaxCommentLength+1)),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Missing question",
+			reqBody:        `{"text": "which version?"}`,
+			callService:    true,
+			expected:       qa.Comment{QuestionID: 3, UserID: 6, Text: "which version?"},
+			mockReturnErr:  fmt.Errorf("storage: %w", qa.ErrQuestionNotFound),
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svcMock := mocks.NewService(t)
+
+			if tc.callService {
+				var created *qa.Comment
+				if tc.mockReturnErr == nil {
+					c := tc.expected
+					c.ID = 11
+					created = &c
+				}
+				svcMock.On("AddComment", tc.expected).
+					Return(created, tc.mockReturnErr).
+					Once()
+			}
+
+			handler := handlers.NewAddCommentHandler(slogdiscard.NewDiscardLogger(), svcMock, tc.onAnswer, "3")
+
+			req := httptest.NewRequest(http.MethodPost, "/comments", bytes.NewReader([]byte(tc.reqBody)))
+			req = req.WithContext(middleware.WithPrincipal(req.Context(), commenter))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expectedStatus, rr.Code)
+
+			if tc.expectedStatus == http.StatusCreated {
+				var resp dto.AddCommentResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				require.Equal(t, uint64(11), resp.Comment.ID)
+				require.Equal(t, tc.expected.Text, resp.Comment.Text)
+			}
+
+			svcMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteCommentHandlerForbidden(t *testing.T) {
+	svcMock := mocks.NewService(t)
+
+	svcMock.On("DeleteComment", uint64(11), qa.Actor{UserID: 6}).Return(qa.ErrForbidden).Once()
+
+	handler := handlers.NewDeleteCommentHandler(slogdiscard.NewDiscardLogger(), svcMock, "11")
+
+	req := httptest.NewRequest(http.MethodDelete, "/comments/11", nil)
+	req = req.WithContext(middleware.WithPrincipal(req.Context(), &auth.Principal{UserID: 6}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusForbidden, rr.Code)
+	svcMock.AssertExpectations(t)
+}
+
+func TestGetQuestionNestsComments(t *testing.T) {
+	svcMock := mocks.NewService(t)
+
+	svcMock.On("GetQuestionWithAnswers", uint64(3), uint64(0)).Return(
+		&qa.Question{ID: 3, Title: "why?", Comments: []qa.Comment{{ID: 1, QuestionID: 3, Text: "which version?"}}},
+		[]qa.Answer{{ID: 5, QuestionID: 3, Text: "because", Comments: []qa.Comment{{ID: 2, AnswerID: 5, Text: "thanks"}}}},
+		nil,
+	).Once()
+
+	handler := handlers.NewGetAllQuestionHandler(slogdiscard.NewDiscardLogger(), svcMock, "3")
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/questions/3", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp dto.QAResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Len(t, resp.Data.Question.Comments, 1)
+	require.Equal(t, "which version?", resp.Data.Question.Comments[0].Text)
+	require.Len(t, resp.Data.Answers, 1)
+	require.Len(t, resp.Data.Answers[0].Comments, 1)
+	require.Equal(t, "thanks", resp.Data.Answers[0].Comments[0].Text)
+
+	svcMock.AssertExpectations(t)
+}
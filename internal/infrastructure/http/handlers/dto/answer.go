@@ -6,8 +6,17 @@ import (
 )
 
 type AnswerResponse struct {
-	Text      string    `json:"text" validate:"required,min=3,max=500"`
-	CreatedAt time.Time `json:"created_at"`
+	ID               uint64            `json:"id"`
+	QuestionID       uint64            `json:"question_id"`
+	UserID           uint64            `json:"user_id"`
+	Text             string            `json:"text" validate:"required,min=3,max=500"`
+	CreatedAt        time.Time         `json:"created_at"`
+	Score            int64             `json:"score"`
+	MyVote           string            `json:"my_vote,omitempty"`
+	AuthorReputation int64             `json:"author_reputation"`
+	Accepted         bool              `json:"accepted"`
+	Comments         []CommentResponse `json:"comments,omitempty"`
+	DeletedAt        *time.Time        `json:"deleted_at,omitempty"`
 }
 
 type AnswerRequest struct {
@@ -22,3 +31,20 @@ type GetAnswerResponse struct {
 	resp.ValidationResponse
 	AnswerResponse
 }
+
+type GetAnswersResponse struct {
+	resp.ValidationResponse
+	Data []AnswerResponse `json:"data"`
+}
+
+type VoteRequest struct {
+	Value string `json:"value" validate:"required,oneof=up down"`
+}
+
+type VoteResponse struct {
+	resp.ValidationResponse
+	QuestionID uint64 `json:"question_id,omitempty"`
+	AnswerID   uint64 `json:"answer_id,omitempty"`
+	Score      int64  `json:"score"`
+	MyVote     string `json:"my_vote,omitempty"`
+}
@@ -0,0 +1,35 @@
+package handlerdto
+
+import (
+	resp "question-answer/pkg/validator"
+	"time"
+)
+
+// CreateAPITokenRequest leaves checking the scopes to auth.ParseScope, which
+// knows every scope.
+type CreateAPITokenRequest struct {
+	Name      string     `json:"name" validate:"required,max=64"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type APITokenResponse struct {
+	ID         uint64     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type CreateAPITokenResponse struct {
+	resp.ValidationResponse
+	Token    string            `json:"token,omitempty"`
+	APIToken *APITokenResponse `json:"api_token,omitempty"`
+}
+
+type ListAPITokensResponse struct {
+	resp.ValidationResponse
+	APITokens []APITokenResponse `json:"api_tokens"`
+}
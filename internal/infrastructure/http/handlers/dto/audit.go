@@ -0,0 +1,22 @@
+package handlerdto
+
+import (
+	resp "question-answer/pkg/validator"
+	"time"
+)
+
+type AuthEventResponse struct {
+	ID        uint64    `json:"id"`
+	UserID    uint64    `json:"user_id,omitempty"`
+	Username  string    `json:"username,omitempty"`
+	Type      string    `json:"type"`
+	IP        string    `json:"ip,omitempty"`
+	UserAgent string    `json:"user_agent,omitempty"`
+	Detail    string    `json:"detail,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ListAuthEventsResponse struct {
+	resp.ValidationResponse
+	Events []AuthEventResponse `json:"events"`
+}
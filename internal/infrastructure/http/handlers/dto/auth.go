@@ -0,0 +1,103 @@
+package handlerdto
+
+import (
+	resp "question-answer/pkg/validator"
+	"time"
+)
+
+type RegisterRequest struct {
+	Username string `json:"username" validate:"required,alphanum,min=3,max=32"`
+	Email    string `json:"email" validate:"omitempty,email,max=254"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
+
+type LoginRequest struct {
+	Username     string `json:"username" validate:"required"`
+	Password     string `json:"password" validate:"required"`
+	OTP          string `json:"otp" validate:"omitempty,numeric,len=6"`
+	RecoveryCode string `json:"recovery_code"`
+}
+
+type UserResponse struct {
+	ID               uint64    `json:"id"`
+	Username         string    `json:"username"`
+	Roles            []string  `json:"roles,omitempty"`
+	TwoFactorEnabled bool      `json:"two_factor_enabled"`
+	CreatedAt        time.Time `json:"created_at"`
+	// Reputation is only filled in on the public profile.
+	Reputation *int64 `json:"reputation,omitempty"`
+}
+
+type RegisterResponse struct {
+	resp.ValidationResponse
+	User *UserResponse `json:"user,omitempty"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type TokenResponse struct {
+	AccessToken      string    `json:"access_token"`
+	TokenType        string    `json:"token_type"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+type LoginResponse struct {
+	resp.ValidationResponse
+	User  *UserResponse  `json:"user,omitempty"`
+	Token *TokenResponse `json:"token,omitempty"`
+	// OTPRequired tells the client to repeat the login with a one-time code.
+	OTPRequired bool `json:"otp_required,omitempty"`
+	// TwoFactorSetupRequired means privileged roles were withheld until the
+	// user enables two-factor authentication.
+	TwoFactorSetupRequired bool `json:"two_factor_setup_required,omitempty"`
+}
+
+type UserProfileResponse struct {
+	resp.ValidationResponse
+	User *UserResponse `json:"user,omitempty"`
+}
+
+type RefreshResponse struct {
+	resp.ValidationResponse
+	Token *TokenResponse `json:"token,omitempty"`
+}
+
+type TOTPCodeRequest struct {
+	Code string `json:"code" validate:"required,numeric,len=6"`
+}
+
+type DisableTOTPRequest struct {
+	Code         string `json:"code" validate:"required_without=RecoveryCode,omitempty,numeric,len=6"`
+	RecoveryCode string `json:"recovery_code"`
+}
+
+// OIDCSecondFactorRequest completes an SSO login of a user with two-factor
+// authentication.
+type OIDCSecondFactorRequest struct {
+	OTP          string `json:"otp" validate:"required_without=RecoveryCode,omitempty,numeric,len=6"`
+	RecoveryCode string `json:"recovery_code"`
+}
+
+type TOTPEnrollResponse struct {
+	resp.ValidationResponse
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+type TOTPConfirmResponse struct {
+	resp.ValidationResponse
+	RecoveryCodes []string `json:"recovery_codes"`
+}
@@ -0,0 +1,29 @@
+package handlerdto
+
+import (
+	resp "question-answer/pkg/validator"
+	"time"
+)
+
+type CommentResponse struct {
+	ID         uint64    `json:"id"`
+	QuestionID uint64    `json:"question_id,omitempty"`
+	AnswerID   uint64    `json:"answer_id,omitempty"`
+	UserID     uint64    `json:"user_id"`
+	Text       string    `json:"text"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type CommentRequest struct {
+	Text string `json:"text" validate:"required,min=2,max=300"`
+}
+
+type AddCommentResponse struct {
+	resp.ValidationResponse
+	Comment CommentResponse `json:"comment"`
+}
+
+type ListCommentsResponse struct {
+	resp.ValidationResponse
+	Data []CommentResponse `json:"data"`
+}
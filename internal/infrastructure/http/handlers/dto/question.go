@@ -6,17 +6,62 @@ import (
 )
 
 type QuestionResponse struct {
-	Text      string    `json:"text" validate:"required,min=1,max=1000"`
-	CreatedAt time.Time `json:"created_at"`
+	ID               uint64            `json:"id"`
+	UserID           uint64            `json:"user_id,omitempty"`
+	Title            string            `json:"title"`
+	Body             string            `json:"body"`
+	BodyHTML         string            `json:"body_html"`
+	CreatedAt        time.Time         `json:"created_at"`
+	AcceptedAnswerID uint64            `json:"accepted_answer_id,omitempty"`
+	Closed           bool              `json:"closed"`
+	DuplicateOfID    uint64            `json:"duplicate_of_id,omitempty"`
+	Tags             []string          `json:"tags,omitempty"`
+	Score            int64             `json:"score"`
+	MyVote           string            `json:"my_vote,omitempty"`
+	Comments         []CommentResponse `json:"comments,omitempty"`
+	DeletedAt        *time.Time        `json:"deleted_at,omitempty"`
 }
 type AddQuestionRequest struct {
-	Text string `json:"text" validate:"required,min=3,max=500"`
+	Title string   `json:"title" validate:"required,min=3,max=150"`
+	Body  string   `json:"body" validate:"max=30000"`
+	Tags  []string `json:"tags,omitempty" validate:"max=5,dive,required,max=32"`
 }
 
 type AddQuestionResponse struct {
 	resp.ValidationResponse
-	Text      string    `json:"text" validate:"required,min=3,max=500"`
-	CreatedAt time.Time `json:"created_at"`
+	ID               uint64    `json:"id,omitempty"`
+	UserID           uint64    `json:"user_id,omitempty"`
+	Title            string    `json:"title"`
+	Body             string    `json:"body"`
+	BodyHTML         string    `json:"body_html"`
+	CreatedAt        time.Time `json:"created_at"`
+	AcceptedAnswerID uint64    `json:"accepted_answer_id,omitempty"`
+	Closed           bool      `json:"closed"`
+	DuplicateOfID    uint64    `json:"duplicate_of_id,omitempty"`
+	Tags             []string  `json:"tags,omitempty"`
+	Score            int64     `json:"score"`
+	// AnswerCount and LastActivityAt are only set in the question list.
+	AnswerCount    int64      `json:"answer_count,omitempty"`
+	LastActivityAt *time.Time `json:"last_activity_at,omitempty"`
+	// Similar is only set when creating with ?similar=true.
+	Similar []SimilarQuestionResponse `json:"similar,omitempty"`
+}
+
+type SimilarQuestionResponse struct {
+	ID               uint64  `json:"id"`
+	Title            string  `json:"title"`
+	AnswerCount      int64   `json:"answer_count"`
+	AcceptedAnswerID uint64  `json:"accepted_answer_id,omitempty"`
+	Score            float64 `json:"score"`
+}
+
+type SimilarQuestionsResponse struct {
+	resp.ValidationResponse
+	Data []SimilarQuestionResponse `json:"data"`
+}
+
+type DuplicateRequest struct {
+	CanonicalID uint64 `json:"canonical_id" validate:"required"`
 }
 
 type DeleteQuestionResponse struct {
@@ -30,4 +75,7 @@ type DeleteQuestionRequest struct {
 type GetQuestionResponse struct {
 	resp.ValidationResponse
 	Data []AddQuestionResponse `json:"data"`
+	// NextCursor is passed as ?after= to get the next page; empty on the
+	// last one.
+	NextCursor string `json:"next_cursor,omitempty"`
 }
@@ -0,0 +1,30 @@
+package handlerdto
+
+import (
+	resp "question-answer/pkg/validator"
+	"time"
+)
+
+// QuestionPatchRequest is the JSON Merge Patch body of PATCH /questions/{id}.
+type QuestionPatchRequest struct {
+	Title *string `json:"title" validate:"omitempty,min=3,max=150"`
+	Body  *string `json:"body" validate:"omitempty,max=30000"`
+}
+
+// AnswerPatchRequest is the JSON Merge Patch body of PATCH /answers/{id}.
+type AnswerPatchRequest struct {
+	Text *string `json:"text" validate:"omitempty,min=3,max=500"`
+}
+
+type RevisionResponse struct {
+	ID            uint64    `json:"id"`
+	EditorID      uint64    `json:"editor_id,omitempty"`
+	PreviousTitle string    `json:"previous_title,omitempty"`
+	PreviousText  string    `json:"previous_text"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type ListRevisionsResponse struct {
+	resp.ValidationResponse
+	Data []RevisionResponse `json:"data"`
+}
@@ -0,0 +1,15 @@
+package handlerdto
+
+import (
+	resp "question-answer/pkg/validator"
+)
+
+type GrantRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=admin moderator"`
+}
+
+type UserRolesResponse struct {
+	resp.ValidationResponse
+	UserID uint64   `json:"user_id"`
+	Roles  []string `json:"roles"`
+}
@@ -0,0 +1,22 @@
+package handlerdto
+
+import (
+	"time"
+
+	resp "question-answer/pkg/validator"
+)
+
+type SearchHitResponse struct {
+	QuestionID uint64 `json:"question_id"`
+	AnswerID   uint64 `json:"answer_id,omitempty"`
+	Title      string `json:"title"`
+	// SnippetHTML is HTML-escaped text with the matched terms in <mark>.
+	SnippetHTML string    `json:"snippet_html"`
+	Rank        float64   `json:"rank"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type SearchResponse struct {
+	resp.ValidationResponse
+	Data []SearchHitResponse `json:"data"`
+}
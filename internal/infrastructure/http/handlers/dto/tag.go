@@ -0,0 +1,18 @@
+package handlerdto
+
+import resp "question-answer/pkg/validator"
+
+type TagResponse struct {
+	Name     string   `json:"name"`
+	Count    int64    `json:"count"`
+	Synonyms []string `json:"synonyms,omitempty"`
+}
+
+type ListTagsResponse struct {
+	resp.ValidationResponse
+	Data []TagResponse `json:"data"`
+}
+
+type AddTagSynonymRequest struct {
+	Synonym string `json:"synonym" validate:"required,max=32"`
+}
@@ -0,0 +1,9 @@
+package handlerdto
+
+import resp "question-answer/pkg/validator"
+
+type TrashResponse struct {
+	resp.ValidationResponse
+	Questions []QuestionResponse `json:"questions"`
+	Answers   []AnswerResponse   `json:"answers"`
+}
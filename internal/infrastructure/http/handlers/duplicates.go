@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"question-answer/internal/domain/qa"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/middleware"
+	"question-answer/pkg/sl_logger/sl"
+)
+
+// POST /questions/{questionID}/duplicate
+func NewMarkDuplicateHandler(log *slog.Logger, svc qa.Service, idStr string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.question.markDuplicate"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		id, err := strconv.ParseUint(idStr, 10, 64)
+		if err != nil {
+			log.Error("failed to convert string", sl.Err(err))
+			authResponseErr(w, http.StatusBadRequest, "invalid question id")
+			return
+		}
+
+		var req dto.DuplicateRequest
+		if !decodeAuthRequest(log, w, r, &req) {
+			return
+		}
+
+		question, err := svc.MarkDuplicate(id, req.CanonicalID, actorFromRequest(r))
+		if err != nil {
+			log.Error("failed to mark duplicate", sl.Err(err))
+			writeQAError(w, err, "failed to mark duplicate")
+			return
+		}
+
+		log.Info("question closed as duplicate",
+			slog.Uint64("question_id", id),
+			slog.Uint64("duplicate_of_id", question.DuplicateOfID),
+		)
+
+		addQuestionResponseOK(w, *question)
+	}
+}
+
+// DELETE /questions/{questionID}/duplicate
+func NewReopenHandler(log *slog.Logger, svc qa.Service, idStr string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.question.reopen"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		id, err := strconv.ParseUint(idStr, 10, 64)
+		if err != nil {
+			log.Error("failed to convert string", sl.Err(err))
+			authResponseErr(w, http.StatusBadRequest, "invalid question id")
+			return
+		}
+
+		question, err := svc.Reopen(id, actorFromRequest(r))
+		if err != nil {
+			log.Error("failed to reopen question", sl.Err(err))
+			writeQAError(w, err, "failed to reopen question")
+			return
+		}
+
+		log.Info("question reopened", slog.Uint64("question_id", id))
+
+		addQuestionResponseOK(w, *question)
+	}
+}
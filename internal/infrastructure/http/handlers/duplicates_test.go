@@ -0,0 +1,119 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"question-answer/internal/domain/qa"
+	auth "question-answer/internal/domain/users"
+	"question-answer/internal/infrastructure/http/handlers"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/handlers/mocks"
+	"question-answer/internal/infrastructure/http/middleware"
+	slogdiscard "question-answer/pkg/sl_logger/slog_discard"
+	validateResp "question-answer/pkg/validator"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkDuplicateHandler(t *testing.T) {
+	moderator := &auth.Principal{UserID: 1, Roles: []auth.Role{auth.RoleModerator}}
+
+	cases := []struct {
+		name           string
+		reqBody        string
+		callService    bool
+		mockReturnErr  error
+		expectedStatus int
+	}{
+		{
+			name:           "Success",
+			reqBody:        `{"canonical_id": 12}`,
+			callService:    true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Missing canonical",
+			reqBody:        `{}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Itself",
+			reqBody:        `{"canonical_id": 12}`,
+			callService:    true,
+			mockReturnErr:  qa.ErrInvalidDuplicate,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Canonical not found",
+			reqBody:        `{"canonical_id": 12}`,
+			callService:    true,
+			mockReturnErr:  fmt.Errorf("storage: %w", qa.ErrQuestionNotFound),
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svcMock := mocks.NewService(t)
+
+			if tc.callService {
+				var closed *qa.Question
+				if tc.mockReturnErr == nil {
+					closed = &qa.Question{ID: 7, Title: "staging db?", DuplicateOfID: 12}
+				}
+				svcMock.On("MarkDuplicate", uint64(7), uint64(12), qa.Actor{UserID: 1, Privileged: true}).
+					Return(closed, tc.mockReturnErr).
+					Once()
+			}
+
+			handler := handlers.NewMarkDuplicateHandler(slogdiscard.NewDiscardLogger(), svcMock, "7")
+
+			req := httptest.NewRequest(http.MethodPost, "/questions/7/duplicate", bytes.NewReader([]byte(tc.reqBody)))
+			req = req.WithContext(middleware.WithPrincipal(req.Context(), moderator))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expectedStatus, rr.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var resp dto.AddQuestionResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				require.True(t, resp.Closed)
+				require.Equal(t, uint64(12), resp.DuplicateOfID)
+			}
+
+			svcMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAddAnswerHandlerClosedQuestion(t *testing.T) {
+	author := &auth.Principal{UserID: 3}
+	closed := fmt.Errorf("%w: duplicate of question 12, answer there instead", qa.ErrQuestionClosed)
+
+	svcMock := mocks.NewService(t)
+	svcMock.On("CreateAnswer", qa.Answer{QuestionID: 7, UserID: 3, Text: "use the vpn"}).
+		Return(uint64(0), closed).
+		Once()
+
+	handler := handlers.NewAddAnswerHandler(slogdiscard.NewDiscardLogger(), svcMock, "7")
+
+	req := httptest.NewRequest(http.MethodPost, "/questions/7/answers", bytes.NewReader([]byte(`{"text": "use the vpn"}`)))
+	req = req.WithContext(middleware.WithPrincipal(req.Context(), author))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusConflict, rr.Code)
+
+	var resp validateResp.ValidationResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Equal(t, closed.Error(), resp.Errors["error"])
+
+	svcMock.AssertExpectations(t)
+}
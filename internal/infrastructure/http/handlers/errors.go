@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"question-answer/internal/domain/qa"
+	auth "question-answer/internal/domain/users"
+	"question-answer/internal/infrastructure/http/middleware"
+	"question-answer/internal/infrastructure/http/transport"
+	validateResp "question-answer/pkg/validator"
+)
+
+// actorFromRequest builds the qa actor from the authenticated principal.
+// Anonymous requests yield a zero Actor, which owns nothing. Moderators act
+// as privileged actors.
+func actorFromRequest(r *http.Request) qa.Actor {
+	p, ok := middleware.GetPrincipal(r)
+	if !ok {
+		return qa.Actor{}
+	}
+	return qa.Actor{
+		UserID:     p.UserID,
+		Privileged: p.HasPermission(auth.PermModerateContent),
+	}
+}
+
+// writeQAError writes the response for an error returned by qa.Service.
+// Known domain errors get a matching status code; anything else is reported
+// as 400 with fallbackMsg.
+func writeQAError(w http.ResponseWriter, err error, fallbackMsg string) {
+	status, msg := http.StatusBadRequest, fallbackMsg
+
+	switch {
+	case errors.Is(err, qa.ErrAnonymous):
+		status, msg = http.StatusUnauthorized, qa.ErrAnonymous.Error()
+	case errors.Is(err, qa.ErrForbidden):
+		status, msg = http.StatusForbidden, qa.ErrForbidden.Error()
+	case errors.Is(err, qa.ErrQuestionNotFound):
+		status, msg = http.StatusNotFound, qa.ErrQuestionNotFound.Error()
+	case errors.Is(err, qa.ErrAnswerNotFound):
+		status, msg = http.StatusNotFound, qa.ErrAnswerNotFound.Error()
+	case errors.Is(err, qa.ErrInvalidVote):
+		status, msg = http.StatusBadRequest, qa.ErrInvalidVote.Error()
+	case errors.Is(err, qa.ErrAnswerMismatch):
+		status, msg = http.StatusBadRequest, qa.ErrAnswerMismatch.Error()
+	case errors.Is(err, qa.ErrQuestionClosed):
+		status, msg = http.StatusConflict, err.Error()
+	case errors.Is(err, qa.ErrInvalidDuplicate):
+		status, msg = http.StatusBadRequest, qa.ErrInvalidDuplicate.Error()
+	case errors.Is(err, qa.ErrQuestionDeleted):
+		status, msg = http.StatusConflict, qa.ErrQuestionDeleted.Error()
+	case errors.Is(err, qa.ErrRevisionNotFound):
+		status, msg = http.StatusNotFound, qa.ErrRevisionNotFound.Error()
+	case errors.Is(err, qa.ErrCommentNotFound):
+		status, msg = http.StatusNotFound, qa.ErrCommentNotFound.Error()
+	case errors.Is(err, qa.ErrInvalidComment):
+		status, msg = http.StatusBadRequest, qa.ErrInvalidComment.Error()
+	case errors.Is(err, qa.ErrCommentTarget):
+		status, msg = http.StatusBadRequest, qa.ErrCommentTarget.Error()
+	case errors.Is(err, qa.ErrInvalidTag):
+		status, msg = http.StatusBadRequest, err.Error()
+	case errors.Is(err, qa.ErrTooManyTags):
+		status, msg = http.StatusBadRequest, qa.ErrTooManyTags.Error()
+	case errors.Is(err, qa.ErrTagNotFound):
+		status, msg = http.StatusNotFound, qa.ErrTagNotFound.Error()
+	case errors.Is(err, qa.ErrTagExists):
+		status, msg = http.StatusConflict, qa.ErrTagExists.Error()
+	case errors.Is(err, qa.ErrInvalidSearch):
+		status, msg = http.StatusBadRequest, qa.ErrInvalidSearch.Error()
+	case errors.Is(err, qa.ErrInvalidSimilarText):
+		status, msg = http.StatusBadRequest, qa.ErrInvalidSimilarText.Error()
+	case errors.Is(err, qa.ErrSelfVote):
+		status, msg = http.StatusForbidden, qa.ErrSelfVote.Error()
+	}
+
+	_ = transport.WriteJSON(w, status, validateResp.Error(msg))
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"question-answer/internal/domain/qa"
+)
+
+// questionFilterFromQuery reads the list filters and order from the query
+// string.
+func questionFilterFromQuery(r *http.Request) (qa.QuestionFilter, error) {
+	q := r.URL.Query()
+	f := qa.QuestionFilter{
+		Tags: q["tag"],
+		Text: q.Get("contains"),
+		Sort: qa.QuestionSort(q.Get("sort")),
+	}
+
+	for name, dst := range map[string]**bool{"answered": &f.Answered, "has_answers": &f.HasAnswers} {
+		if v := q.Get(name); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return f, fmt.Errorf("invalid %s filter %q", name, v)
+			}
+			*dst = &b
+		}
+	}
+
+	if v := q.Get("author"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil || id == 0 {
+			return f, fmt.Errorf("invalid author filter %q", v)
+		}
+		f.AuthorID = id
+	}
+
+	for name, dst := range map[string]**time.Time{"created_from": &f.CreatedFrom, "created_to": &f.CreatedTo} {
+		if v := q.Get(name); v != "" {
+			t, err := parseFilterTime(v)
+			if err != nil {
+				return f, fmt.Errorf("invalid %s filter %q", name, v)
+			}
+			*dst = &t
+		}
+	}
+
+	switch v := q.Get("order"); v {
+	case "", "asc":
+	case "desc":
+		f.Desc = true
+	default:
+		return f, fmt.Errorf("invalid order %q", v)
+	}
+
+	return f, nil
+}
+
+// parseFilterTime accepts an RFC 3339 timestamp or a bare date, which means
+// its midnight UTC.
+func parseFilterTime(v string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, v); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, v)
+}
+
+// pageFromQuery reads the ?limit= and ?after= paging parameters.
+func pageFromQuery(r *http.Request) (qa.PageRequest, error) {
+	q := r.URL.Query()
+	p := qa.PageRequest{After: q.Get("after")}
+
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 {
+			return p, fmt.Errorf("invalid limit %q", v)
+		}
+		p.Limit = limit
+	}
+
+	return p, nil
+}
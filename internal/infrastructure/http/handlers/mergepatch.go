@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"mime"
+	"net/http"
+	"slices"
+
+	"question-answer/internal/infrastructure/http/transport"
+	"question-answer/pkg/sl_logger/sl"
+	validateResp "question-answer/pkg/validator"
+
+	"github.com/go-playground/validator"
+)
+
+const mergePatchContentType = "application/merge-patch+json"
+
+// decodeMergePatch decodes a JSON Merge Patch (RFC 7396) into req. Only the
+// editable top-level fields may appear, and since none of them can be
+// removed a null value is rejected too. Like decodeAuthRequest it writes the
+// error response itself and reports whether the handler may continue.
+func decodeMergePatch(log *slog.Logger, w http.ResponseWriter, r *http.Request, editable []string, req any) bool {
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		mediaType, _, err := mime.ParseMediaType(ct)
+		if err != nil || (mediaType != mergePatchContentType && mediaType != "application/json") {
+			authResponseErr(w, http.StatusUnsupportedMediaType, "content type must be "+mergePatchContentType)
+			return false
+		}
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Error("failed to read body", sl.Err(err))
+		authResponseErr(w, http.StatusBadRequest, transport.ErrFailedToDecodeReqBody.Error())
+		return false
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		authResponseErr(w, http.StatusBadRequest, transport.ErrEmptyReqBody.Error())
+		return false
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		log.Error("bad request",
+			slog.String("type", transport.ErrFailedToDecodeReqBody.Error()),
+			sl.Err(err),
+		)
+		authResponseErr(w, http.StatusBadRequest, transport.ErrFailedToDecodeReqBody.Error())
+		return false
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(fields)) {
+		value := fields[name]
+		if !slices.Contains(editable, name) {
+			authResponseErr(w, http.StatusBadRequest, fmt.Sprintf("field %q cannot be changed", name))
+			return false
+		}
+		if string(bytes.TrimSpace(value)) == "null" {
+			authResponseErr(w, http.StatusBadRequest, fmt.Sprintf("field %q cannot be removed", name))
+			return false
+		}
+	}
+
+	if err := json.Unmarshal(body, req); err != nil {
+		log.Error("bad request",
+			slog.String("type", transport.ErrFailedToDecodeReqBody.Error()),
+			sl.Err(err),
+		)
+		authResponseErr(w, http.StatusBadRequest, transport.ErrFailedToDecodeReqBody.Error())
+		return false
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		validateErr := err.(validator.ValidationErrors)
+		log.Error("invalid request", sl.Err(err))
+		_ = transport.WriteJSON(w, http.StatusBadRequest, validateResp.ValidationError(validateErr))
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,559 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	auth "question-answer/internal/domain/users"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// AuthService is an autogenerated mock type for the Service type
+type AuthService struct {
+	mock.Mock
+}
+
+// Authenticate provides a mock function with given fields: token
+func (_m *AuthService) Authenticate(token string) (*auth.Principal, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authenticate")
+	}
+
+	var r0 *auth.Principal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*auth.Principal, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) *auth.Principal); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.Principal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConfirmTOTP provides a mock function with given fields: p, code
+func (_m *AuthService) ConfirmTOTP(p auth.Principal, code string) ([]string, error) {
+	ret := _m.Called(p, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmTOTP")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(auth.Principal, string) ([]string, error)); ok {
+		return rf(p, code)
+	}
+	if rf, ok := ret.Get(0).(func(auth.Principal, string) []string); ok {
+		r0 = rf(p, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(auth.Principal, string) error); ok {
+		r1 = rf(p, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateAPIToken provides a mock function with given fields: p, name, scopes, expiresAt
+func (_m *AuthService) CreateAPIToken(p auth.Principal, name string, scopes []auth.Scope, expiresAt *time.Time) (*auth.APIToken, string, error) {
+	ret := _m.Called(p, name, scopes, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAPIToken")
+	}
+
+	var r0 *auth.APIToken
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(auth.Principal, string, []auth.Scope, *time.Time) (*auth.APIToken, string, error)); ok {
+		return rf(p, name, scopes, expiresAt)
+	}
+	if rf, ok := ret.Get(0).(func(auth.Principal, string, []auth.Scope, *time.Time) *auth.APIToken); ok {
+		r0 = rf(p, name, scopes, expiresAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.APIToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(auth.Principal, string, []auth.Scope, *time.Time) string); ok {
+		r1 = rf(p, name, scopes, expiresAt)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(auth.Principal, string, []auth.Scope, *time.Time) error); ok {
+		r2 = rf(p, name, scopes, expiresAt)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DisableTOTP provides a mock function with given fields: p, code, recoveryCode
+func (_m *AuthService) DisableTOTP(p auth.Principal, code string, recoveryCode string) error {
+	ret := _m.Called(p, code, recoveryCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableTOTP")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(auth.Principal, string, string) error); ok {
+		r0 = rf(p, code, recoveryCode)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnrollTOTP provides a mock function with given fields: p
+func (_m *AuthService) EnrollTOTP(p auth.Principal) (*auth.TOTPEnrollment, error) {
+	ret := _m.Called(p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnrollTOTP")
+	}
+
+	var r0 *auth.TOTPEnrollment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(auth.Principal) (*auth.TOTPEnrollment, error)); ok {
+		return rf(p)
+	}
+	if rf, ok := ret.Get(0).(func(auth.Principal) *auth.TOTPEnrollment); ok {
+		r0 = rf(p)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.TOTPEnrollment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(auth.Principal) error); ok {
+		r1 = rf(p)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ForgotPassword provides a mock function with given fields: email
+func (_m *AuthService) ForgotPassword(email string) error {
+	ret := _m.Called(email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForgotPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetUser provides a mock function with given fields: id
+func (_m *AuthService) GetUser(id uint64) (*auth.User, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 *auth.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) (*auth.User, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) *auth.User); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserRoles provides a mock function with given fields: userID
+func (_m *AuthService) GetUserRoles(userID uint64) ([]auth.Role, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserRoles")
+	}
+
+	var r0 []auth.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) ([]auth.Role, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) []auth.Role); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auth.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GrantRole provides a mock function with given fields: actor, userID, role
+func (_m *AuthService) GrantRole(actor auth.Principal, userID uint64, role auth.Role) error {
+	ret := _m.Called(actor, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GrantRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(auth.Principal, uint64, auth.Role) error); ok {
+		r0 = rf(actor, userID, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListAPITokens provides a mock function with given fields: p
+func (_m *AuthService) ListAPITokens(p auth.Principal) ([]auth.APIToken, error) {
+	ret := _m.Called(p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAPITokens")
+	}
+
+	var r0 []auth.APIToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(auth.Principal) ([]auth.APIToken, error)); ok {
+		return rf(p)
+	}
+	if rf, ok := ret.Get(0).(func(auth.Principal) []auth.APIToken); ok {
+		r0 = rf(p)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auth.APIToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(auth.Principal) error); ok {
+		r1 = rf(p)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAuthEvents provides a mock function with given fields: f
+func (_m *AuthService) ListAuthEvents(f auth.AuthEventFilter) ([]auth.AuthEvent, error) {
+	ret := _m.Called(f)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAuthEvents")
+	}
+
+	var r0 []auth.AuthEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(auth.AuthEventFilter) ([]auth.AuthEvent, error)); ok {
+		return rf(f)
+	}
+	if rf, ok := ret.Get(0).(func(auth.AuthEventFilter) []auth.AuthEvent); ok {
+		r0 = rf(f)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auth.AuthEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(auth.AuthEventFilter) error); ok {
+		r1 = rf(f)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Login provides a mock function with given fields: in
+func (_m *AuthService) Login(in auth.LoginInput) (*auth.User, *auth.Tokens, error) {
+	ret := _m.Called(in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Login")
+	}
+
+	var r0 *auth.User
+	var r1 *auth.Tokens
+	var r2 error
+	if rf, ok := ret.Get(0).(func(auth.LoginInput) (*auth.User, *auth.Tokens, error)); ok {
+		return rf(in)
+	}
+	if rf, ok := ret.Get(0).(func(auth.LoginInput) *auth.User); ok {
+		r0 = rf(in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(auth.LoginInput) *auth.Tokens); ok {
+		r1 = rf(in)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*auth.Tokens)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(auth.LoginInput) error); ok {
+		r2 = rf(in)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// LoginExternal provides a mock function with given fields: in
+func (_m *AuthService) LoginExternal(in auth.ExternalLoginInput) (*auth.User, *auth.Tokens, error) {
+	ret := _m.Called(in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoginExternal")
+	}
+
+	var r0 *auth.User
+	var r1 *auth.Tokens
+	var r2 error
+	if rf, ok := ret.Get(0).(func(auth.ExternalLoginInput) (*auth.User, *auth.Tokens, error)); ok {
+		return rf(in)
+	}
+	if rf, ok := ret.Get(0).(func(auth.ExternalLoginInput) *auth.User); ok {
+		r0 = rf(in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(auth.ExternalLoginInput) *auth.Tokens); ok {
+		r1 = rf(in)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*auth.Tokens)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(auth.ExternalLoginInput) error); ok {
+		r2 = rf(in)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Logout provides a mock function with given fields: p, c
+func (_m *AuthService) Logout(p auth.Principal, c auth.Client) error {
+	ret := _m.Called(p, c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(auth.Principal, auth.Client) error); ok {
+		r0 = rf(p, c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LogoutAll provides a mock function with given fields: p, c
+func (_m *AuthService) LogoutAll(p auth.Principal, c auth.Client) error {
+	ret := _m.Called(p, c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogoutAll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(auth.Principal, auth.Client) error); ok {
+		r0 = rf(p, c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Refresh provides a mock function with given fields: refreshToken
+func (_m *AuthService) Refresh(refreshToken string) (*auth.Tokens, error) {
+	ret := _m.Called(refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 *auth.Tokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*auth.Tokens, error)); ok {
+		return rf(refreshToken)
+	}
+	if rf, ok := ret.Get(0).(func(string) *auth.Tokens); ok {
+		r0 = rf(refreshToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.Tokens)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Register provides a mock function with given fields: username, email, password
+func (_m *AuthService) Register(username string, email string, password string) (*auth.User, error) {
+	ret := _m.Called(username, email, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Register")
+	}
+
+	var r0 *auth.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (*auth.User, error)); ok {
+		return rf(username, email, password)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) *auth.User); ok {
+		r0 = rf(username, email, password)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(username, email, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResetPassword provides a mock function with given fields: token, newPassword, c
+func (_m *AuthService) ResetPassword(token string, newPassword string, c auth.Client) error {
+	ret := _m.Called(token, newPassword, c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, auth.Client) error); ok {
+		r0 = rf(token, newPassword, c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeAPIToken provides a mock function with given fields: p, id
+func (_m *AuthService) RevokeAPIToken(p auth.Principal, id uint64) error {
+	ret := _m.Called(p, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAPIToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(auth.Principal, uint64) error); ok {
+		r0 = rf(p, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeRole provides a mock function with given fields: actor, userID, role
+func (_m *AuthService) RevokeRole(actor auth.Principal, userID uint64, role auth.Role) error {
+	ret := _m.Called(actor, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(auth.Principal, uint64, auth.Role) error); ok {
+		r0 = rf(actor, userID, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewAuthService creates a new instance of AuthService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuthService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuthService {
+	mock := &AuthService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
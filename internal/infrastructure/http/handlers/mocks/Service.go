@@ -6,6 +6,8 @@ import (
 	qa "question-answer/internal/domain/qa"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -13,6 +15,84 @@ type Service struct {
 	mock.Mock
 }
 
+// AcceptAnswer provides a mock function with given fields: questionID, answerID, actor
+func (_m *Service) AcceptAnswer(questionID uint64, answerID uint64, actor qa.Actor) (*qa.Question, error) {
+	ret := _m.Called(questionID, answerID, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptAnswer")
+	}
+
+	var r0 *qa.Question
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, uint64, qa.Actor) (*qa.Question, error)); ok {
+		return rf(questionID, answerID, actor)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, uint64, qa.Actor) *qa.Question); ok {
+		r0 = rf(questionID, answerID, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*qa.Question)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, uint64, qa.Actor) error); ok {
+		r1 = rf(questionID, answerID, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddComment provides a mock function with given fields: c
+func (_m *Service) AddComment(c qa.Comment) (*qa.Comment, error) {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddComment")
+	}
+
+	var r0 *qa.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(qa.Comment) (*qa.Comment, error)); ok {
+		return rf(c)
+	}
+	if rf, ok := ret.Get(0).(func(qa.Comment) *qa.Comment); ok {
+		r0 = rf(c)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*qa.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(qa.Comment) error); ok {
+		r1 = rf(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddTagSynonym provides a mock function with given fields: tag, synonym, actor
+func (_m *Service) AddTagSynonym(tag string, synonym string, actor qa.Actor) error {
+	ret := _m.Called(tag, synonym, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTagSynonym")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, qa.Actor) error); ok {
+		r0 = rf(tag, synonym, actor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateAnswer provides a mock function with given fields: a
 func (_m *Service) CreateAnswer(a qa.Answer) (uint64, error) {
 	ret := _m.Called(a)
@@ -71,17 +151,17 @@ func (_m *Service) CreateQuestion(q qa.Question) (*qa.Question, error) {
 	return r0, r1
 }
 
-// DeleteAnswer provides a mock function with given fields: id
-func (_m *Service) DeleteAnswer(id uint64) error {
-	ret := _m.Called(id)
+// DeleteAnswer provides a mock function with given fields: id, actor
+func (_m *Service) DeleteAnswer(id uint64, actor qa.Actor) error {
+	ret := _m.Called(id, actor)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteAnswer")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint64) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(uint64, qa.Actor) error); ok {
+		r0 = rf(id, actor)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -89,17 +169,35 @@ func (_m *Service) DeleteAnswer(id uint64) error {
 	return r0
 }
 
-// DeleteQuestion provides a mock function with given fields: id
-func (_m *Service) DeleteQuestion(id uint64) error {
-	ret := _m.Called(id)
+// DeleteComment provides a mock function with given fields: id, actor
+func (_m *Service) DeleteComment(id uint64, actor qa.Actor) error {
+	ret := _m.Called(id, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteComment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, qa.Actor) error); ok {
+		r0 = rf(id, actor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteQuestion provides a mock function with given fields: id, actor
+func (_m *Service) DeleteQuestion(id uint64, actor qa.Actor) error {
+	ret := _m.Called(id, actor)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteQuestion")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint64) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(uint64, qa.Actor) error); ok {
+		r0 = rf(id, actor)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -107,29 +205,89 @@ func (_m *Service) DeleteQuestion(id uint64) error {
 	return r0
 }
 
-// GetAllQuestions provides a mock function with no fields
-func (_m *Service) GetAllQuestions() ([]qa.Question, error) {
-	ret := _m.Called()
+// EditAnswer provides a mock function with given fields: id, actor, p
+func (_m *Service) EditAnswer(id uint64, actor qa.Actor, p qa.AnswerPatch) (*qa.Answer, error) {
+	ret := _m.Called(id, actor, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditAnswer")
+	}
+
+	var r0 *qa.Answer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, qa.Actor, qa.AnswerPatch) (*qa.Answer, error)); ok {
+		return rf(id, actor, p)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, qa.Actor, qa.AnswerPatch) *qa.Answer); ok {
+		r0 = rf(id, actor, p)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*qa.Answer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, qa.Actor, qa.AnswerPatch) error); ok {
+		r1 = rf(id, actor, p)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EditQuestion provides a mock function with given fields: id, actor, p
+func (_m *Service) EditQuestion(id uint64, actor qa.Actor, p qa.QuestionPatch) (*qa.Question, error) {
+	ret := _m.Called(id, actor, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditQuestion")
+	}
+
+	var r0 *qa.Question
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, qa.Actor, qa.QuestionPatch) (*qa.Question, error)); ok {
+		return rf(id, actor, p)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, qa.Actor, qa.QuestionPatch) *qa.Question); ok {
+		r0 = rf(id, actor, p)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*qa.Question)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, qa.Actor, qa.QuestionPatch) error); ok {
+		r1 = rf(id, actor, p)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllQuestions provides a mock function with given fields: f, page
+func (_m *Service) GetAllQuestions(f qa.QuestionFilter, page qa.PageRequest) (*qa.QuestionPage, error) {
+	ret := _m.Called(f, page)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAllQuestions")
 	}
 
-	var r0 []qa.Question
+	var r0 *qa.QuestionPage
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]qa.Question, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(qa.QuestionFilter, qa.PageRequest) (*qa.QuestionPage, error)); ok {
+		return rf(f, page)
 	}
-	if rf, ok := ret.Get(0).(func() []qa.Question); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(qa.QuestionFilter, qa.PageRequest) *qa.QuestionPage); ok {
+		r0 = rf(f, page)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]qa.Question)
+			r0 = ret.Get(0).(*qa.QuestionPage)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(qa.QuestionFilter, qa.PageRequest) error); ok {
+		r1 = rf(f, page)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -167,10 +325,160 @@ func (_m *Service) GetAnswer(id uint64) (*qa.Answer, error) {
 	return r0, r1
 }
 
-// GetQuestionWithAnswers provides a mock function with given fields: id
-func (_m *Service) GetQuestionWithAnswers(id uint64) (*qa.Question, []qa.Answer, error) {
+// GetAnswerComments provides a mock function with given fields: answerID
+func (_m *Service) GetAnswerComments(answerID uint64) ([]qa.Comment, error) {
+	ret := _m.Called(answerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAnswerComments")
+	}
+
+	var r0 []qa.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) ([]qa.Comment, error)); ok {
+		return rf(answerID)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) []qa.Comment); ok {
+		r0 = rf(answerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]qa.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(answerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAnswerRevisions provides a mock function with given fields: id
+func (_m *Service) GetAnswerRevisions(id uint64) ([]qa.Revision, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAnswerRevisions")
+	}
+
+	var r0 []qa.Revision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) ([]qa.Revision, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) []qa.Revision); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]qa.Revision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAnswersByUser provides a mock function with given fields: userID
+func (_m *Service) GetAnswersByUser(userID uint64) ([]qa.Answer, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAnswersByUser")
+	}
+
+	var r0 []qa.Answer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) ([]qa.Answer, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) []qa.Answer); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]qa.Answer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetQuestionComments provides a mock function with given fields: questionID
+func (_m *Service) GetQuestionComments(questionID uint64) ([]qa.Comment, error) {
+	ret := _m.Called(questionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQuestionComments")
+	}
+
+	var r0 []qa.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) ([]qa.Comment, error)); ok {
+		return rf(questionID)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) []qa.Comment); ok {
+		r0 = rf(questionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]qa.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(questionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetQuestionRevisions provides a mock function with given fields: id
+func (_m *Service) GetQuestionRevisions(id uint64) ([]qa.Revision, error) {
 	ret := _m.Called(id)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetQuestionRevisions")
+	}
+
+	var r0 []qa.Revision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) ([]qa.Revision, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) []qa.Revision); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]qa.Revision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetQuestionWithAnswers provides a mock function with given fields: id, viewerID
+func (_m *Service) GetQuestionWithAnswers(id uint64, viewerID uint64) (*qa.Question, []qa.Answer, error) {
+	ret := _m.Called(id, viewerID)
+
 	if len(ret) == 0 {
 		panic("no return value specified for GetQuestionWithAnswers")
 	}
@@ -178,27 +486,27 @@ func (_m *Service) GetQuestionWithAnswers(id uint64) (*qa.Question, []qa.Answer,
 	var r0 *qa.Question
 	var r1 []qa.Answer
 	var r2 error
-	if rf, ok := ret.Get(0).(func(uint64) (*qa.Question, []qa.Answer, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(uint64, uint64) (*qa.Question, []qa.Answer, error)); ok {
+		return rf(id, viewerID)
 	}
-	if rf, ok := ret.Get(0).(func(uint64) *qa.Question); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(uint64, uint64) *qa.Question); ok {
+		r0 = rf(id, viewerID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*qa.Question)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uint64) []qa.Answer); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(uint64, uint64) []qa.Answer); ok {
+		r1 = rf(id, viewerID)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).([]qa.Answer)
 		}
 	}
 
-	if rf, ok := ret.Get(2).(func(uint64) error); ok {
-		r2 = rf(id)
+	if rf, ok := ret.Get(2).(func(uint64, uint64) error); ok {
+		r2 = rf(id, viewerID)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -206,6 +514,540 @@ func (_m *Service) GetQuestionWithAnswers(id uint64) (*qa.Question, []qa.Answer,
 	return r0, r1, r2
 }
 
+// GetQuestionsByUser provides a mock function with given fields: userID
+func (_m *Service) GetQuestionsByUser(userID uint64) ([]qa.Question, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQuestionsByUser")
+	}
+
+	var r0 []qa.Question
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) ([]qa.Question, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) []qa.Question); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]qa.Question)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReputation provides a mock function with given fields: userID
+func (_m *Service) GetReputation(userID uint64) (int64, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReputation")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) (int64, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) int64); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTags provides a mock function with no fields
+func (_m *Service) ListTags() ([]qa.Tag, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTags")
+	}
+
+	var r0 []qa.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]qa.Tag, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []qa.Tag); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]qa.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTrash provides a mock function with given fields: actor
+func (_m *Service) ListTrash(actor qa.Actor) (*qa.Trash, error) {
+	ret := _m.Called(actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTrash")
+	}
+
+	var r0 *qa.Trash
+	var r1 error
+	if rf, ok := ret.Get(0).(func(qa.Actor) (*qa.Trash, error)); ok {
+		return rf(actor)
+	}
+	if rf, ok := ret.Get(0).(func(qa.Actor) *qa.Trash); ok {
+		r0 = rf(actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*qa.Trash)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(qa.Actor) error); ok {
+		r1 = rf(actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkDuplicate provides a mock function with given fields: id, canonicalID, actor
+func (_m *Service) MarkDuplicate(id uint64, canonicalID uint64, actor qa.Actor) (*qa.Question, error) {
+	ret := _m.Called(id, canonicalID, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkDuplicate")
+	}
+
+	var r0 *qa.Question
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, uint64, qa.Actor) (*qa.Question, error)); ok {
+		return rf(id, canonicalID, actor)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, uint64, qa.Actor) *qa.Question); ok {
+		r0 = rf(id, canonicalID, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*qa.Question)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, uint64, qa.Actor) error); ok {
+		r1 = rf(id, canonicalID, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeTrash provides a mock function with given fields: cutoff
+func (_m *Service) PurgeTrash(cutoff time.Time) (int64, error) {
+	ret := _m.Called(cutoff)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeTrash")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (int64, error)); ok {
+		return rf(cutoff)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = rf(cutoff)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(cutoff)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecalculateReputation provides a mock function with no fields
+func (_m *Service) RecalculateReputation() (int64, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecalculateReputation")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (int64, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reopen provides a mock function with given fields: id, actor
+func (_m *Service) Reopen(id uint64, actor qa.Actor) (*qa.Question, error) {
+	ret := _m.Called(id, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reopen")
+	}
+
+	var r0 *qa.Question
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, qa.Actor) (*qa.Question, error)); ok {
+		return rf(id, actor)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, qa.Actor) *qa.Question); ok {
+		r0 = rf(id, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*qa.Question)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, qa.Actor) error); ok {
+		r1 = rf(id, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreAnswer provides a mock function with given fields: id, actor
+func (_m *Service) RestoreAnswer(id uint64, actor qa.Actor) (*qa.Answer, error) {
+	ret := _m.Called(id, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreAnswer")
+	}
+
+	var r0 *qa.Answer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, qa.Actor) (*qa.Answer, error)); ok {
+		return rf(id, actor)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, qa.Actor) *qa.Answer); ok {
+		r0 = rf(id, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*qa.Answer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, qa.Actor) error); ok {
+		r1 = rf(id, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreQuestion provides a mock function with given fields: id, actor
+func (_m *Service) RestoreQuestion(id uint64, actor qa.Actor) (*qa.Question, error) {
+	ret := _m.Called(id, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreQuestion")
+	}
+
+	var r0 *qa.Question
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, qa.Actor) (*qa.Question, error)); ok {
+		return rf(id, actor)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, qa.Actor) *qa.Question); ok {
+		r0 = rf(id, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*qa.Question)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, qa.Actor) error); ok {
+		r1 = rf(id, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RollbackAnswer provides a mock function with given fields: id, revisionID, actor
+func (_m *Service) RollbackAnswer(id uint64, revisionID uint64, actor qa.Actor) (*qa.Answer, error) {
+	ret := _m.Called(id, revisionID, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RollbackAnswer")
+	}
+
+	var r0 *qa.Answer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, uint64, qa.Actor) (*qa.Answer, error)); ok {
+		return rf(id, revisionID, actor)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, uint64, qa.Actor) *qa.Answer); ok {
+		r0 = rf(id, revisionID, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*qa.Answer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, uint64, qa.Actor) error); ok {
+		r1 = rf(id, revisionID, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RollbackQuestion provides a mock function with given fields: id, revisionID, actor
+func (_m *Service) RollbackQuestion(id uint64, revisionID uint64, actor qa.Actor) (*qa.Question, error) {
+	ret := _m.Called(id, revisionID, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RollbackQuestion")
+	}
+
+	var r0 *qa.Question
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, uint64, qa.Actor) (*qa.Question, error)); ok {
+		return rf(id, revisionID, actor)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, uint64, qa.Actor) *qa.Question); ok {
+		r0 = rf(id, revisionID, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*qa.Question)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, uint64, qa.Actor) error); ok {
+		r1 = rf(id, revisionID, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Search provides a mock function with given fields: query
+func (_m *Service) Search(query string) ([]qa.SearchHit, error) {
+	ret := _m.Called(query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []qa.SearchHit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]qa.SearchHit, error)); ok {
+		return rf(query)
+	}
+	if rf, ok := ret.Get(0).(func(string) []qa.SearchHit); ok {
+		r0 = rf(query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]qa.SearchHit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SimilarQuestions provides a mock function with given fields: text, excludeID
+func (_m *Service) SimilarQuestions(text string, excludeID uint64) ([]qa.SimilarQuestion, error) {
+	ret := _m.Called(text, excludeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SimilarQuestions")
+	}
+
+	var r0 []qa.SimilarQuestion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, uint64) ([]qa.SimilarQuestion, error)); ok {
+		return rf(text, excludeID)
+	}
+	if rf, ok := ret.Get(0).(func(string, uint64) []qa.SimilarQuestion); ok {
+		r0 = rf(text, excludeID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]qa.SimilarQuestion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, uint64) error); ok {
+		r1 = rf(text, excludeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Unvote provides a mock function with given fields: answerID, actor
+func (_m *Service) Unvote(answerID uint64, actor qa.Actor) (*qa.Answer, error) {
+	ret := _m.Called(answerID, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unvote")
+	}
+
+	var r0 *qa.Answer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, qa.Actor) (*qa.Answer, error)); ok {
+		return rf(answerID, actor)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, qa.Actor) *qa.Answer); ok {
+		r0 = rf(answerID, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*qa.Answer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, qa.Actor) error); ok {
+		r1 = rf(answerID, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnvoteQuestion provides a mock function with given fields: questionID, actor
+func (_m *Service) UnvoteQuestion(questionID uint64, actor qa.Actor) (*qa.Question, error) {
+	ret := _m.Called(questionID, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnvoteQuestion")
+	}
+
+	var r0 *qa.Question
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, qa.Actor) (*qa.Question, error)); ok {
+		return rf(questionID, actor)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, qa.Actor) *qa.Question); ok {
+		r0 = rf(questionID, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*qa.Question)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, qa.Actor) error); ok {
+		r1 = rf(questionID, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Vote provides a mock function with given fields: answerID, actor, v
+func (_m *Service) Vote(answerID uint64, actor qa.Actor, v qa.Vote) (*qa.Answer, error) {
+	ret := _m.Called(answerID, actor, v)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Vote")
+	}
+
+	var r0 *qa.Answer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, qa.Actor, qa.Vote) (*qa.Answer, error)); ok {
+		return rf(answerID, actor, v)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, qa.Actor, qa.Vote) *qa.Answer); ok {
+		r0 = rf(answerID, actor, v)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*qa.Answer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, qa.Actor, qa.Vote) error); ok {
+		r1 = rf(answerID, actor, v)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VoteQuestion provides a mock function with given fields: questionID, actor, v
+func (_m *Service) VoteQuestion(questionID uint64, actor qa.Actor, v qa.Vote) (*qa.Question, error) {
+	ret := _m.Called(questionID, actor, v)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VoteQuestion")
+	}
+
+	var r0 *qa.Question
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, qa.Actor, qa.Vote) (*qa.Question, error)); ok {
+		return rf(questionID, actor, v)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, qa.Actor, qa.Vote) *qa.Question); ok {
+		r0 = rf(questionID, actor, v)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*qa.Question)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, qa.Actor, qa.Vote) error); ok {
+		r1 = rf(questionID, actor, v)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewService(t interface {
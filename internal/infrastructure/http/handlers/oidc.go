@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	auth "question-answer/internal/domain/users"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/middleware"
+	"question-answer/internal/infrastructure/http/transport"
+	"question-answer/internal/infrastructure/oidc"
+	"question-answer/pkg/sl_logger/sl"
+	validateResp "question-answer/pkg/validator"
+)
+
+const (
+	oidcStateCookie = "qa_oidc_state"
+	// oidcChallengeCookie binds a login waiting for the second factor to
+	// the identity the provider verified.
+	oidcChallengeCookie = "qa_oidc_2fa"
+)
+
+// OIDCProvider is the part of *oidc.Provider the handlers use.
+type OIDCProvider interface {
+	AuthCodeURL(state, nonce string) string
+	Exchange(ctx context.Context, code, nonce string) (*auth.ExternalIdentity, error)
+}
+
+// GET /auth/oidc/login
+func NewOIDCLoginHandler(log *slog.Logger, provider OIDCProvider, states *oidc.StateCodec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.oidc.login"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		state, nonce, cookie, err := states.New()
+		if err != nil {
+			log.Error("failed to create oidc state", sl.Err(err))
+			authResponseErr(w, http.StatusInternalServerError, "failed to start login")
+			return
+		}
+
+		http.SetCookie(w, oidcCookie(r, oidcStateCookie, cookie, int(states.TTL().Seconds())))
+
+		http.Redirect(w, r, provider.AuthCodeURL(state, nonce), http.StatusFound)
+	}
+}
+
+// GET /auth/oidc/callback
+func NewOIDCCallbackHandler(log *slog.Logger, svc auth.Service, provider OIDCProvider, states *oidc.StateCodec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.oidc.callback"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		q := r.URL.Query()
+		if e := q.Get("error"); e != "" {
+			log.Info("identity provider refused login",
+				slog.String("error", e),
+				slog.String("description", q.Get("error_description")),
+			)
+			authResponseErr(w, http.StatusUnauthorized, "login was cancelled or refused by the identity provider")
+			return
+		}
+
+		// The state cookie is single use whatever the outcome.
+		http.SetCookie(w, oidcCookie(r, oidcStateCookie, "", -1))
+
+		cookie, err := r.Cookie(oidcStateCookie)
+		if err != nil {
+			authResponseErr(w, http.StatusBadRequest, oidc.ErrInvalidState.Error())
+			return
+		}
+
+		nonce, err := states.Verify(cookie.Value, q.Get("state"))
+		if err != nil {
+			log.Info("oidc state rejected", sl.Err(err))
+			authResponseErr(w, http.StatusBadRequest, oidc.ErrInvalidState.Error())
+			return
+		}
+
+		code := q.Get("code")
+		if code == "" {
+			authResponseErr(w, http.StatusBadRequest, "missing authorization code")
+			return
+		}
+
+		id, err := provider.Exchange(r.Context(), code, nonce)
+		if err != nil {
+			log.Warn("oidc exchange failed", sl.Err(err))
+			authResponseErr(w, http.StatusUnauthorized, "failed to verify identity")
+			return
+		}
+
+		user, tokens, err := svc.LoginExternal(auth.ExternalLoginInput{Identity: *id, Client: clientFromRequest(r)})
+		if errors.Is(err, auth.ErrOTPRequired) {
+			// The authorization code is spent, so the second factor is
+			// sent to /auth/oidc/2fa along with a cookie naming the
+			// verified identity.
+			challenge, cerr := states.NewChallenge(id.Issuer, id.Subject)
+			if cerr != nil {
+				log.Error("failed to create two-factor challenge", sl.Err(cerr))
+				authResponseErr(w, http.StatusInternalServerError, "failed to login")
+				return
+			}
+			http.SetCookie(w, oidcCookie(r, oidcChallengeCookie, challenge, int(states.TTL().Seconds())))
+		}
+		if errors.Is(err, auth.ErrUserExists) {
+			log.Error("failed to login external user", sl.Err(err))
+			authResponseErr(w, http.StatusConflict, "failed to login")
+			return
+		}
+		if err != nil {
+			writeLoginError(log.With(slog.String("subject", id.Subject)), w, err)
+			return
+		}
+
+		log.Info("user logged in via oidc", slog.Uint64("user_id", user.ID))
+
+		oidcLoginResponseOK(w, user, tokens)
+	}
+}
+
+// POST /auth/oidc/2fa
+func NewOIDCSecondFactorHandler(log *slog.Logger, svc auth.Service, states *oidc.StateCodec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.oidc.secondFactor"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		cookie, err := r.Cookie(oidcChallengeCookie)
+		if err != nil {
+			authResponseErr(w, http.StatusUnauthorized, oidc.ErrInvalidChallenge.Error())
+			return
+		}
+
+		issuer, subject, err := states.VerifyChallenge(cookie.Value)
+		if err != nil {
+			log.Info("two-factor challenge rejected", sl.Err(err))
+			authResponseErr(w, http.StatusUnauthorized, oidc.ErrInvalidChallenge.Error())
+			return
+		}
+
+		var req dto.OIDCSecondFactorRequest
+		if !decodeAuthRequest(log, w, r, &req) {
+			return
+		}
+
+		// A wrong code may be retried with the same cookie until it
+		// expires; the login lockout bounds the guesses.
+		user, tokens, err := svc.LoginExternal(auth.ExternalLoginInput{
+			Identity:     auth.ExternalIdentity{Issuer: issuer, Subject: subject},
+			OTP:          req.OTP,
+			RecoveryCode: req.RecoveryCode,
+			Client:       clientFromRequest(r),
+		})
+		if err != nil {
+			writeLoginError(log.With(slog.String("subject", subject)), w, err)
+			return
+		}
+
+		http.SetCookie(w, oidcCookie(r, oidcChallengeCookie, "", -1))
+
+		log.Info("user logged in via oidc", slog.Uint64("user_id", user.ID))
+
+		oidcLoginResponseOK(w, user, tokens)
+	}
+}
+
+func oidcLoginResponseOK(w http.ResponseWriter, user *auth.User, tokens *auth.Tokens) {
+	transport.WriteJSON(w, http.StatusOK, dto.LoginResponse{
+		ValidationResponse:     validateResp.OK(),
+		User:                   toUserResponse(user),
+		Token:                  toTokenResponse(tokens),
+		TwoFactorSetupRequired: tokens.TwoFactorSetupRequired,
+	})
+}
+
+// oidcCookie returns a cookie of the OIDC login flow. A negative maxAge
+// deletes it.
+func oidcCookie(r *http.Request, name, value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/auth/oidc",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
@@ -0,0 +1,182 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	auth "question-answer/internal/domain/users"
+	"question-answer/internal/infrastructure/http/handlers"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/handlers/mocks"
+	"question-answer/internal/infrastructure/oidc"
+	"question-answer/internal/infrastructure/oidc/oidctest"
+	slogdiscard "question-answer/pkg/sl_logger/slog_discard"
+	validateresp "question-answer/pkg/validator"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOIDCLogin(t *testing.T) {
+	idp := oidctest.NewServer("qa-client", "qa-secret", oidctest.User{
+		Subject:           "user-42",
+		Email:             "gopher@example.com",
+		EmailVerified:     true,
+		PreferredUsername: "gopher",
+	})
+	defer idp.Close()
+
+	provider, err := oidc.New(context.Background(), oidc.Config{
+		IssuerURL:    idp.Issuer(),
+		ClientID:     "qa-client",
+		ClientSecret: "qa-secret",
+		RedirectURL:  "http://localhost:8082/auth/oidc/callback",
+	}, idp.Client())
+	require.NoError(t, err)
+
+	states := oidc.NewStateCodec([]byte("state-secret"), time.Minute)
+	log := slogdiscard.NewDiscardLogger()
+
+	// Start the login: we get redirected to the provider with a state cookie.
+	rr := httptest.NewRecorder()
+	handlers.NewOIDCLoginHandler(log, provider, states).
+		ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/auth/oidc/login", nil))
+	require.Equal(t, http.StatusFound, rr.Code)
+
+	cookies := rr.Result().Cookies()
+	require.Len(t, cookies, 1)
+
+	// The provider signs the user in and redirects back with a code.
+	noRedirect := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := noRedirect.Get(rr.Header().Get("Location"))
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+
+	svcMock := mocks.NewAuthService(t)
+	svcMock.On("LoginExternal", mock.MatchedBy(func(in auth.ExternalLoginInput) bool {
+		id := in.Identity
+		return id.Issuer == idp.Issuer() && id.Subject == "user-42" && id.EmailVerified && in.OTP == ""
+	})).Return(
+		&auth.User{ID: 7, Username: "gopher"},
+		&auth.Tokens{AccessToken: "access", RefreshToken: "refresh"},
+		nil,
+	).Once()
+
+	handler := handlers.NewOIDCCallbackHandler(log, svcMock, provider, states)
+
+	// Without the cookie the callback is rejected.
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, callback.RequestURI(), nil))
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+
+	req := httptest.NewRequest(http.MethodGet, callback.RequestURI(), nil)
+	req.AddCookie(cookies[0])
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var body dto.LoginResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	require.Equal(t, validateresp.StatusOK, body.Status)
+	require.Equal(t, "access", body.Token.AccessToken)
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestOIDCSecondFactor(t *testing.T) {
+	idp := oidctest.NewServer("qa-client", "qa-secret", oidctest.User{Subject: "admin-1"})
+	defer idp.Close()
+
+	provider, err := oidc.New(context.Background(), oidc.Config{
+		IssuerURL:    idp.Issuer(),
+		ClientID:     "qa-client",
+		ClientSecret: "qa-secret",
+		RedirectURL:  "http://localhost:8082/auth/oidc/callback",
+	}, idp.Client())
+	require.NoError(t, err)
+
+	states := oidc.NewStateCodec([]byte("state-secret"), time.Minute)
+	log := slogdiscard.NewDiscardLogger()
+
+	rr := httptest.NewRecorder()
+	handlers.NewOIDCLoginHandler(log, provider, states).
+		ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/auth/oidc/login", nil))
+	stateCookie := rr.Result().Cookies()[0]
+
+	noRedirect := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := noRedirect.Get(rr.Header().Get("Location"))
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+
+	svcMock := mocks.NewAuthService(t)
+	svcMock.On("LoginExternal", mock.MatchedBy(func(in auth.ExternalLoginInput) bool {
+		return in.Identity.Subject == "admin-1" && in.OTP == ""
+	})).Return(nil, nil, fmt.Errorf("login: %w", auth.ErrOTPRequired)).Once()
+	svcMock.On("LoginExternal", auth.ExternalLoginInput{
+		Identity: auth.ExternalIdentity{Issuer: idp.Issuer(), Subject: "admin-1"},
+		OTP:      "123456",
+		Client:   auth.Client{IP: "192.0.2.1"},
+	}).Return(
+		&auth.User{ID: 1, Username: "admin"},
+		&auth.Tokens{AccessToken: "access", RefreshToken: "refresh"},
+		nil,
+	).Once()
+
+	// The callback does not sign the user in but hands out a challenge.
+	req := httptest.NewRequest(http.MethodGet, callback.RequestURI(), nil)
+	req.AddCookie(stateCookie)
+	rr = httptest.NewRecorder()
+	handlers.NewOIDCCallbackHandler(log, svcMock, provider, states).ServeHTTP(rr, req)
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+
+	var body dto.LoginResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	require.True(t, body.OTPRequired)
+	require.Nil(t, body.Token)
+
+	var challenge *http.Cookie
+	for _, c := range rr.Result().Cookies() {
+		if c.Name == "qa_oidc_2fa" {
+			challenge = c
+		}
+	}
+	require.NotNil(t, challenge)
+
+	handler := handlers.NewOIDCSecondFactorHandler(log, svcMock, states)
+
+	// Without the challenge the code alone is not enough.
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/auth/oidc/2fa", strings.NewReader(`{"otp": "123456"}`)))
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/auth/oidc/2fa", strings.NewReader(`{"otp": "123456"}`))
+	req.RemoteAddr = "192.0.2.1:51234"
+	req.AddCookie(challenge)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	body = dto.LoginResponse{}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	require.Equal(t, "access", body.Token.AccessToken)
+
+	svcMock.AssertExpectations(t)
+}
@@ -4,13 +4,16 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"question-answer/internal/domain/qa"
+	auth "question-answer/internal/domain/users"
 
 	"question-answer/internal/infrastructure/http/handlers"
 	dto "question-answer/internal/infrastructure/http/handlers/dto"
 	"question-answer/internal/infrastructure/http/handlers/mocks"
+	"question-answer/internal/infrastructure/http/middleware"
 	slogdiscard "question-answer/pkg/sl_logger/slog_discard"
 	validateresp "question-answer/pkg/validator"
 
@@ -34,23 +37,23 @@ func TestAddQuestionHandler(t *testing.T) {
 	}{
 		{
 			name:    "Success",
-			reqBody: `{"text": "Почему небо голубое?"}`,
+			reqBody: `{"title": "Почему небо голубое?"}`,
 			mockReturnQ: &qa.Question{
 				ID:        123,
-				Text:      "Почему небо голубое?",
+				Title:     "Почему небо голубое?",
 				CreatedAt: fixedTime,
 			},
 			mockReturnErr:  nil,
 			expectedStatus: http.StatusOK,
 			expectedResp: dto.AddQuestionResponse{
 				ValidationResponse: validateresp.OK(), 
-				Text:               "Почему небо голубое?",
+				Title:              "Почему небо голубое?",
 				CreatedAt:          fixedTime,
 			},
 		},
 		{
 			name:           "Invalid JSON",
-			reqBody:        `{"text": "valid"`,
+			reqBody:        `{"title": "valid"`,
 			expectedStatus: http.StatusBadRequest,
 			expectedResp: dto.AddQuestionResponse{
 				ValidationResponse: validateresp.Error("failed to decode request body"),
@@ -58,7 +61,7 @@ func TestAddQuestionHandler(t *testing.T) {
 		},
 		{
 			name:           "Service error",
-			reqBody:        `{"text": "Этот вопрос упадёт"}`,
+			reqBody:        `{"title": "Этот вопрос упадёт"}`,
 			mockReturnQ:    nil,
 			mockReturnErr:  errors.New("db down"),
 			expectedStatus: http.StatusBadRequest,
@@ -93,7 +96,7 @@ func TestAddQuestionHandler(t *testing.T) {
 
 			require.Equal(t, tc.expectedResp.Status, resp.Status)
 			require.Equal(t, tc.expectedResp.Errors, resp.Errors)
-			require.Equal(t, tc.expectedResp.Text, resp.Text)
+			require.Equal(t, tc.expectedResp.Title, resp.Title)
 
 			if tc.expectedStatus == http.StatusOK {
 				require.WithinDuration(t, tc.expectedResp.CreatedAt, resp.CreatedAt, time.Second)
@@ -103,3 +106,126 @@ func TestAddQuestionHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteQuestionHandler(t *testing.T) {
+	owner := &auth.Principal{UserID: 7, Username: "owner"}
+
+	cases := []struct {
+		name           string
+		principal      *auth.Principal
+		mockReturnErr  error
+		expectedStatus int
+	}{
+		{
+			name:           "Owner",
+			principal:      owner,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Someone else",
+			principal:      &auth.Principal{UserID: 8, Username: "intruder"},
+			mockReturnErr:  qa.ErrForbidden,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Missing question",
+			principal:      owner,
+			mockReturnErr:  fmt.Errorf("storage: %w", qa.ErrQuestionNotFound),
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svcMock := mocks.NewService(t)
+			svcMock.On("DeleteQuestion", uint64(5), qa.Actor{UserID: tc.principal.UserID}).
+				Return(tc.mockReturnErr).
+				Once()
+
+			handler := handlers.NewDeleteQuestionHandler(slogdiscard.NewDiscardLogger(), svcMock, "5")
+
+			req, err := http.NewRequest(http.MethodDelete, "/questions/5", nil)
+			require.NoError(t, err)
+			req = req.WithContext(middleware.WithPrincipal(req.Context(), tc.principal))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expectedStatus, rr.Code)
+			svcMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetQuestionHandlerPagination(t *testing.T) {
+	svcMock := mocks.NewService(t)
+
+	svcMock.On("GetAllQuestions", qa.QuestionFilter{}, qa.PageRequest{Limit: 1, After: "abc"}).
+		Return(&qa.QuestionPage{Questions: []qa.Question{{ID: 3, Title: "third"}}, NextCursor: "def"}, nil).
+		Once()
+	svcMock.On("GetAllQuestions", qa.QuestionFilter{}, qa.PageRequest{After: "garbage"}).
+		Return(nil, qa.ErrInvalidCursor).
+		Once()
+
+	handler := handlers.NewGetQuestionHandler(slogdiscard.NewDiscardLogger(), svcMock)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/questions?limit=1&after=abc", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp dto.GetQuestionResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Len(t, resp.Data, 1)
+	require.Equal(t, "def", resp.NextCursor)
+
+	for _, target := range []string{"/questions?after=garbage", "/questions?limit=0", "/questions?limit=ten"} {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, target, nil))
+		require.Equal(t, http.StatusBadRequest, rr.Code, target)
+	}
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestGetQuestionHandlerSortAndFilter(t *testing.T) {
+	svcMock := mocks.NewService(t)
+
+	unanswered := false
+	weekAgo := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	svcMock.On("GetAllQuestions", qa.QuestionFilter{
+		HasAnswers:  &unanswered,
+		AuthorID:    42,
+		CreatedFrom: &weekAgo,
+		Text:        "pgx",
+		Sort:        qa.SortCreated,
+		Desc:        true,
+	}, qa.PageRequest{}).
+		Return(&qa.QuestionPage{}, nil).
+		Once()
+	svcMock.On("GetAllQuestions", qa.QuestionFilter{Sort: "score"}, qa.PageRequest{}).
+		Return(nil, fmt.Errorf("%w: unknown sort %q", qa.ErrInvalidFilter, "score")).
+		Once()
+
+	handler := handlers.NewGetQuestionHandler(slogdiscard.NewDiscardLogger(), svcMock)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet,
+		"/questions?has_answers=false&author=42&created_from=2026-01-05&contains=pgx&sort=created&order=desc", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/questions?sort=score", nil))
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var resp dto.GetQuestionResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Equal(t, `invalid filter: unknown sort "score"`, resp.Errors["error"])
+
+	for _, target := range []string{"/questions?order=up", "/questions?author=me", "/questions?created_to=yesterday"} {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, target, nil))
+		require.Equal(t, http.StatusBadRequest, rr.Code, target)
+	}
+
+	svcMock.AssertExpectations(t)
+}
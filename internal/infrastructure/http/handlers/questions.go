@@ -6,16 +6,17 @@ import (
 	dto "question-answer/internal/infrastructure/http/handlers/dto"
 	"question-answer/internal/infrastructure/http/middleware"
 	"question-answer/internal/infrastructure/http/transport"
+	"question-answer/pkg/markdown"
 	"question-answer/pkg/sl_logger/sl"
 	validateResp "question-answer/pkg/validator"
 	"strconv"
-	"time"
 
 	"encoding/json"
 	"errors"
 	"io"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/go-playground/validator"
 )
@@ -64,7 +65,12 @@ func NewAddQuestionHandler(log *slog.Logger, svc qa.Service) http.HandlerFunc {
 		}
 
 		respQuestion := qa.Question{
-			Text: req.Text,
+			Title: req.Title,
+			Body:  req.Body,
+			Tags:  req.Tags,
+		}
+		if p, ok := middleware.GetPrincipal(r); ok {
+			respQuestion.UserID = p.UserID
 		}
 
 		reqQuestion, err := svc.CreateQuestion(respQuestion)
@@ -72,13 +78,25 @@ func NewAddQuestionHandler(log *slog.Logger, svc qa.Service) http.HandlerFunc {
 			log.Error("failed to add quest",
 				sl.Err(err),
 			)
-			addQuestionResponseErr(w, transport.ErrFailedToDecodeReqBody.Error())
+			writeQAError(w, err, transport.ErrFailedToDecodeReqBody.Error())
 			return
 		}
 
-		log.Info("quest added", slog.Any("title", reqQuestion.Text))
+		log.Info("quest added", slog.Any("title", reqQuestion.Title))
 
-		addQuestionResponseOK(w, reqQuestion.Text, reqQuestion.CreatedAt)
+		var similar []qa.SimilarQuestion
+		if ok, _ := strconv.ParseBool(r.URL.Query().Get("similar")); ok {
+			// The question is already posted; failing to find look-alikes
+			// only costs the suggestions.
+			similar, err = svc.SimilarQuestions(reqQuestion.Title, reqQuestion.ID)
+			if err != nil {
+				log.Warn("failed to find similar questions", sl.Err(err))
+			}
+		}
+
+		resp := toAddQuestionResponse(*reqQuestion)
+		resp.Similar = toSimilarQuestionResponses(similar)
+		transport.WriteJSON(w, http.StatusOK, resp)
 	}
 }
 
@@ -97,16 +115,37 @@ func NewGetQuestionHandler(log *slog.Logger, svc qa.Service) http.HandlerFunc {
 			slog.String("request_id", middleware.GetRequestID(r)),
 		)
 
-		reqQuestions, err := svc.GetAllQuestions()
+		filter, err := questionFilterFromQuery(r)
+		if err != nil {
+			log.Error("invalid filter", sl.Err(err))
+			getQuestionResponseErr(w, err.Error())
+			return
+		}
+		page, err := pageFromQuery(r)
+		if err != nil {
+			log.Error("invalid page", sl.Err(err))
+			getQuestionResponseErr(w, err.Error())
+			return
+		}
+
+		reqQuestions, err := svc.GetAllQuestions(filter, page)
 		if err != nil {
 			log.Error("failed to add quest",
 				sl.Err(err),
 			)
+			if errors.Is(err, qa.ErrInvalidTag) {
+				getQuestionResponseErr(w, qa.ErrInvalidTag.Error())
+				return
+			}
+			if errors.Is(err, qa.ErrInvalidFilter) || errors.Is(err, qa.ErrInvalidCursor) || errors.Is(err, qa.ErrInvalidPageSize) {
+				getQuestionResponseErr(w, err.Error())
+				return
+			}
 			getQuestionResponseErr(w, transport.ErrFailedToDecodeReqBody.Error())
 			return
 		}
 
-		log.Info("quest added", slog.Any("quest arr geeted", len(reqQuestions)))
+		log.Info("quest added", slog.Any("quest arr geeted", len(reqQuestions.Questions)))
 
 		getQuestionResponseOK(w, reqQuestions)
 	}
@@ -136,7 +175,7 @@ func NewGetAllQuestionHandler(log *slog.Logger, svc qa.Service, idStr string) ht
 			return
 		}
 
-		question, answers, err := svc.GetQuestionWithAnswers(uint64(id))
+		question, answers, err := svc.GetQuestionWithAnswers(uint64(id), actorFromRequest(r).UserID)
 		if err != nil {
 			log.Error("failed to get quest",
 				sl.Err(err),
@@ -145,7 +184,7 @@ func NewGetAllQuestionHandler(log *slog.Logger, svc qa.Service, idStr string) ht
 			return
 		}
 
-		log.Info("quest added", slog.Any("question-answer", question.Text))
+		log.Info("quest added", slog.Any("question-answer", question.Title))
 
 		getQAResponseOK(w, *question, answers)
 	})
@@ -173,11 +212,11 @@ func NewDeleteQuestionHandler(log *slog.Logger, svc qa.Service, idStr string) ht
 			return
 		}
 		
-		if err = svc.DeleteQuestion(uint64(id)); err != nil {
+		if err = svc.DeleteQuestion(uint64(id), actorFromRequest(r)); err != nil {
 			log.Error("failed to delete quest",
 				sl.Err(err),
 			)
-			deleteQuestionResponseErr(w, transport.ErrFailedToDecodeReqBody.Error())
+			writeQAError(w, err, "failed to delete question")
 			return
 		}
 
@@ -189,13 +228,25 @@ func NewDeleteQuestionHandler(log *slog.Logger, svc qa.Service, idStr string) ht
 }
 
 // Post Quest
-func addQuestionResponseOK(w http.ResponseWriter, text string, created time.Time) {
-	r := dto.AddQuestionResponse{
+func addQuestionResponseOK(w http.ResponseWriter, q qa.Question) {
+	transport.WriteJSON(w, http.StatusOK, toAddQuestionResponse(q))
+}
+
+func toAddQuestionResponse(q qa.Question) dto.AddQuestionResponse {
+	return dto.AddQuestionResponse{
 		ValidationResponse: validateResp.OK(),
-		Text:               text,
-		CreatedAt:          created,
+		ID:                 q.ID,
+		UserID:             q.UserID,
+		Title:              q.Title,
+		Body:               q.Body,
+		BodyHTML:           markdown.Render(q.Body),
+		CreatedAt:          q.CreatedAt,
+		AcceptedAnswerID:   q.AcceptedAnswerID,
+		Closed:             q.Closed(),
+		DuplicateOfID:      q.DuplicateOfID,
+		Tags:               q.Tags,
+		Score:              q.Score,
 	}
-	transport.WriteJSON(w, http.StatusOK, r)
 }
 
 func addQuestionResponseErr(w http.ResponseWriter, q string) {
@@ -206,21 +257,41 @@ func addQuestionResponseErr(w http.ResponseWriter, q string) {
 }
 
 // Get Q
-func getQuestionResponseOK(w http.ResponseWriter, q []qa.Question) {
+func getQuestionResponseOK(w http.ResponseWriter, page *qa.QuestionPage) {
 	data := make([]dto.AddQuestionResponse, 0)
-	for _, v := range q {
+	for _, v := range page.Questions {
 		data = append(data, dto.AddQuestionResponse{
-			Text:      v.Text,
-			CreatedAt: v.CreatedAt,
+			ID:               v.ID,
+			UserID:           v.UserID,
+			Title:            v.Title,
+			Body:             v.Body,
+			BodyHTML:         markdown.Render(v.Body),
+			CreatedAt:        v.CreatedAt,
+			AcceptedAnswerID: v.AcceptedAnswerID,
+			Closed:           v.Closed(),
+			DuplicateOfID:    v.DuplicateOfID,
+			Tags:             v.Tags,
+			Score:            v.Score,
+			AnswerCount:      v.AnswerCount,
+			LastActivityAt:   timeOrNil(v.LastActivityAt),
 		})
 	}
 	r := dto.GetQuestionResponse{
 		ValidationResponse: validateResp.OK(),
 		Data:               data,
+		NextCursor:         page.NextCursor,
 	}
 	transport.WriteJSON(w, http.StatusOK, r)
 }
 
+// timeOrNil hides times that were not loaded.
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
 func getQuestionResponseErr(w http.ResponseWriter, q string) {
 	r := dto.GetQuestionResponse{
 		ValidationResponse: validateResp.Error(q),
@@ -231,21 +302,25 @@ func getQuestionResponseErr(w http.ResponseWriter, q string) {
 
 // Get QA
 func getQAResponseOK(w http.ResponseWriter, q qa.Question, a []qa.Answer) {
-	answers := make([]dto.AnswerResponse, 0, len(a))
-	for _, v := range a {
-		answers = append(answers, dto.AnswerResponse{
-			Text:      v.Text,
-			CreatedAt: v.CreatedAt,
-		})
-	}
 	r := dto.QAResponse{
 		ValidationResponse: validateResp.OK(),
 		Data: dto.QAData{
 			Question: dto.QuestionResponse{
-				Text:      q.Text,
-				CreatedAt: q.CreatedAt,
+				ID:               q.ID,
+				UserID:           q.UserID,
+				Title:            q.Title,
+				Body:             q.Body,
+				BodyHTML:         markdown.Render(q.Body),
+				CreatedAt:        q.CreatedAt,
+				AcceptedAnswerID: q.AcceptedAnswerID,
+				Closed:           q.Closed(),
+				DuplicateOfID:    q.DuplicateOfID,
+				Tags:             q.Tags,
+				Score:            q.Score,
+				MyVote:           q.ViewerVote.String(),
+				Comments:         toCommentResponses(q.Comments),
 			},
-			Answers: answers,
+			Answers: toAnswerResponses(a),
 		},
 	}
 	transport.WriteJSON(w, http.StatusOK, r)
@@ -259,16 +334,9 @@ func getQAResponseErr(w http.ResponseWriter, q string) {
 	transport.WriteJSON(w, http.StatusBadRequest, r)
 }
 
-func deleteQuestionResponseErr(w http.ResponseWriter, q string) {
-	r := dto.DeleteQuestionResponse{
-		ValidationResponse: validateResp.Error(q),
-	}
-	transport.WriteJSON(w, http.StatusBadRequest, r)
-}
-
 func deleteQuestionResponseOK(w http.ResponseWriter) {
 	r := dto.DeleteQuestionResponse{
 		ValidationResponse: validateResp.OK(),
 	}
-	transport.WriteJSON(w, http.StatusBadRequest, r)
+	transport.WriteJSON(w, http.StatusOK, r)
 }
@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"question-answer/internal/domain/qa"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/middleware"
+	"question-answer/internal/infrastructure/http/transport"
+	"question-answer/pkg/sl_logger/sl"
+	validateResp "question-answer/pkg/validator"
+)
+
+// PATCH /questions/{questionID}
+func NewEditQuestionHandler(log *slog.Logger, svc qa.Service, idStr string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.question.edit"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		id, err := strconv.ParseUint(idStr, 10, 64)
+		if err != nil {
+			log.Error("failed to convert string", sl.Err(err))
+			authResponseErr(w, http.StatusBadRequest, "invalid question id")
+			return
+		}
+
+		var req dto.QuestionPatchRequest
+		if !decodeMergePatch(log, w, r, []string{"title", "body"}, &req) {
+			return
+		}
+
+		question, err := svc.EditQuestion(id, actorFromRequest(r), qa.QuestionPatch{
+			Title: req.Title,
+			Body:  req.Body,
+		})
+		if err != nil {
+			log.Error("failed to edit question", sl.Err(err))
+			writeQAError(w, err, "failed to edit question")
+			return
+		}
+
+		log.Info("question edited", slog.Uint64("question_id", id))
+
+		addQuestionResponseOK(w, *question)
+	}
+}
+
+// PATCH /answers/{answerID}
+func NewEditAnswerHandler(log *slog.Logger, svc qa.Service, idStr string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.answer.edit"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		id, err := strconv.ParseUint(idStr, 10, 64)
+		if err != nil {
+			log.Error("failed to convert string", sl.Err(err))
+			authResponseErr(w, http.StatusBadRequest, "invalid answer id")
+			return
+		}
+
+		var req dto.AnswerPatchRequest
+		if !decodeMergePatch(log, w, r, []string{"text"}, &req) {
+			return
+		}
+
+		answer, err := svc.EditAnswer(id, actorFromRequest(r), qa.AnswerPatch{Text: req.Text})
+		if err != nil {
+			log.Error("failed to edit answer", sl.Err(err))
+			writeQAError(w, err, "failed to edit answer")
+			return
+		}
+
+		log.Info("answer edited", slog.Uint64("answer_id", id))
+
+		getAnswerResponseOK(w, *answer)
+	}
+}
+
+// GET /questions/{questionID}/revisions and /answers/{answerID}/revisions
+func NewListRevisionsHandler(log *slog.Logger, svc qa.Service, onAnswer bool, idStr string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.revisions.list"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		id, err := strconv.ParseUint(idStr, 10, 64)
+		if err != nil {
+			log.Error("failed to convert string", sl.Err(err))
+			authResponseErr(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		var revisions []qa.Revision
+		if onAnswer {
+			revisions, err = svc.GetAnswerRevisions(id)
+		} else {
+			revisions, err = svc.GetQuestionRevisions(id)
+		}
+		if err != nil {
+			log.Error("failed to list revisions", sl.Err(err))
+			writeQAError(w, err, "failed to list revisions")
+			return
+		}
+
+		data := make([]dto.RevisionResponse, 0, len(revisions))
+		for _, rev := range revisions {
+			data = append(data, dto.RevisionResponse{
+				ID:            rev.ID,
+				EditorID:      rev.EditorID,
+				PreviousTitle: rev.PreviousTitle,
+				PreviousText:  rev.PreviousText,
+				CreatedAt:     rev.CreatedAt,
+			})
+		}
+
+		transport.WriteJSON(w, http.StatusOK, dto.ListRevisionsResponse{
+			ValidationResponse: validateResp.OK(),
+			Data:               data,
+		})
+	}
+}
+
+// POST /questions/{questionID}/revisions/{revisionID}/rollback
+func NewRollbackQuestionHandler(log *slog.Logger, svc qa.Service, idStr, revisionIDStr string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.question.rollback"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		id, revisionID, ok := parseRevisionPath(log, w, idStr, revisionIDStr)
+		if !ok {
+			return
+		}
+
+		question, err := svc.RollbackQuestion(id, revisionID, actorFromRequest(r))
+		if err != nil {
+			log.Error("failed to roll back question", sl.Err(err))
+			writeQAError(w, err, "failed to roll back question")
+			return
+		}
+
+		log.Info("question rolled back",
+			slog.Uint64("question_id", id),
+			slog.Uint64("revision_id", revisionID),
+		)
+
+		addQuestionResponseOK(w, *question)
+	}
+}
+
+// POST /answers/{answerID}/revisions/{revisionID}/rollback
+func NewRollbackAnswerHandler(log *slog.Logger, svc qa.Service, idStr, revisionIDStr string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.answer.rollback"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		id, revisionID, ok := parseRevisionPath(log, w, idStr, revisionIDStr)
+		if !ok {
+			return
+		}
+
+		answer, err := svc.RollbackAnswer(id, revisionID, actorFromRequest(r))
+		if err != nil {
+			log.Error("failed to roll back answer", sl.Err(err))
+			writeQAError(w, err, "failed to roll back answer")
+			return
+		}
+
+		log.Info("answer rolled back",
+			slog.Uint64("answer_id", id),
+			slog.Uint64("revision_id", revisionID),
+		)
+
+		getAnswerResponseOK(w, *answer)
+	}
+}
+
+func parseRevisionPath(log *slog.Logger, w http.ResponseWriter, idStr, revisionIDStr string) (uint64, uint64, bool) {
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		log.Error("failed to convert string", sl.Err(err))
+		authResponseErr(w, http.StatusBadRequest, "invalid id")
+		return 0, 0, false
+	}
+	revisionID, err := strconv.ParseUint(revisionIDStr, 10, 64)
+	if err != nil {
+		log.Error("failed to convert string", sl.Err(err))
+		authResponseErr(w, http.StatusBadRequest, "invalid revision id")
+		return 0, 0, false
+	}
+	return id, revisionID, true
+}
@@ -0,0 +1,179 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"question-answer/internal/domain/qa"
+	auth "question-answer/internal/domain/users"
+	"question-answer/internal/infrastructure/http/handlers"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/handlers/mocks"
+	"question-answer/internal/infrastructure/http/middleware"
+	slogdiscard "question-answer/pkg/sl_logger/slog_discard"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditQuestionHandler(t *testing.T) {
+	editor := &auth.Principal{UserID: 4, Username: "asker"}
+	newTitle := "how do I tune pgx pools?"
+	newBody := "Setting **MaxConns** did not help."
+
+	cases := []struct {
+		name           string
+		contentType    string
+		reqBody        string
+		callService    bool
+		patch          qa.QuestionPatch
+		mockReturnErr  error
+		expectedStatus int
+	}{
+		{
+			name:           "Merge patch",
+			contentType:    "application/merge-patch+json",
+			reqBody:        `{"title": "how do I tune pgx pools?"}`,
+			callService:    true,
+			patch:          qa.QuestionPatch{Title: &newTitle},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Body patch",
+			contentType:    "application/merge-patch+json",
+			reqBody:        `{"body": "Setting **MaxConns** did not help."}`,
+			callService:    true,
+			patch:          qa.QuestionPatch{Body: &newBody},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Empty patch",
+			contentType:    "application/merge-patch+json",
+			reqBody:        `{}`,
+			callService:    true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Read-only field",
+			contentType:    "application/merge-patch+json",
+			reqBody:        `{"user_id": 1}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Removing title",
+			contentType:    "application/merge-patch+json",
+			reqBody:        `{"title": null}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Too short",
+			contentType:    "application/merge-patch+json",
+			reqBody:        `{"title": "?"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Wrong content type",
+			contentType:    "text/plain",
+			reqBody:        `{"title": "how do I tune pgx pools?"}`,
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:           "Not the author",
+			contentType:    "application/merge-patch+json",
+			reqBody:        `{"title": "how do I tune pgx pools?"}`,
+			callService:    true,
+			patch:          qa.QuestionPatch{Title: &newTitle},
+			mockReturnErr:  qa.ErrForbidden,
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svcMock := mocks.NewService(t)
+
+			if tc.callService {
+				var edited *qa.Question
+				if tc.mockReturnErr == nil {
+					edited = &qa.Question{ID: 2, UserID: 4, Title: newTitle, Body: newBody}
+				}
+				svcMock.On("EditQuestion", uint64(2), qa.Actor{UserID: editor.UserID}, tc.patch).
+					Return(edited, tc.mockReturnErr).
+					Once()
+			}
+
+			handler := handlers.NewEditQuestionHandler(slogdiscard.NewDiscardLogger(), svcMock, "2")
+
+			req := httptest.NewRequest(http.MethodPatch, "/questions/2", bytes.NewReader([]byte(tc.reqBody)))
+			req.Header.Set("Content-Type", tc.contentType)
+			req = req.WithContext(middleware.WithPrincipal(req.Context(), editor))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expectedStatus, rr.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var resp dto.AddQuestionResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				require.Equal(t, newTitle, resp.Title)
+				require.Contains(t, resp.BodyHTML, "<strong>MaxConns</strong>")
+			}
+
+			svcMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListRevisionsHandler(t *testing.T) {
+	svcMock := mocks.NewService(t)
+
+	svcMock.On("GetQuestionRevisions", uint64(2)).Return([]qa.Revision{
+		{ID: 7, QuestionID: 2, EditorID: 4, PreviousTitle: "pgx pools?", PreviousText: "Which settings matter?"},
+	}, nil).Once()
+	svcMock.On("GetAnswerRevisions", uint64(2)).Return(nil, qa.ErrAnswerNotFound).Once()
+
+	rr := httptest.NewRecorder()
+	handlers.NewListRevisionsHandler(slogdiscard.NewDiscardLogger(), svcMock, false, "2").
+		ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/questions/2/revisions", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp dto.ListRevisionsResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Len(t, resp.Data, 1)
+	require.Equal(t, "pgx pools?", resp.Data[0].PreviousTitle)
+	require.Equal(t, "Which settings matter?", resp.Data[0].PreviousText)
+	require.Equal(t, uint64(4), resp.Data[0].EditorID)
+
+	rr = httptest.NewRecorder()
+	handlers.NewListRevisionsHandler(slogdiscard.NewDiscardLogger(), svcMock, true, "2").
+		ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/answers/2/revisions", nil))
+	require.Equal(t, http.StatusNotFound, rr.Code)
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestRollbackQuestionHandler(t *testing.T) {
+	svcMock := mocks.NewService(t)
+	editor := &auth.Principal{UserID: 4}
+
+	svcMock.On("RollbackQuestion", uint64(2), uint64(7), qa.Actor{UserID: 4}).
+		Return(&qa.Question{ID: 2, UserID: 4, Title: "pgx pools?"}, nil).
+		Once()
+	svcMock.On("RollbackQuestion", uint64(2), uint64(8), qa.Actor{UserID: 4}).
+		Return(nil, qa.ErrRevisionNotFound).
+		Once()
+
+	for revision, status := range map[string]int{"7": http.StatusOK, "8": http.StatusNotFound, "x": http.StatusBadRequest} {
+		req := httptest.NewRequest(http.MethodPost, "/questions/2/revisions/"+revision+"/rollback", nil)
+		req = req.WithContext(middleware.WithPrincipal(req.Context(), editor))
+
+		rr := httptest.NewRecorder()
+		handlers.NewRollbackQuestionHandler(slogdiscard.NewDiscardLogger(), svcMock, "2", revision).ServeHTTP(rr, req)
+		require.Equal(t, status, rr.Code, "revision %s", revision)
+	}
+
+	svcMock.AssertExpectations(t)
+}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	auth "question-answer/internal/domain/users"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/middleware"
+	"question-answer/internal/infrastructure/http/transport"
+	"question-answer/pkg/sl_logger/sl"
+	validateResp "question-answer/pkg/validator"
+)
+
+// GET /admin/users/{userID}/roles
+func NewGetUserRolesHandler(log *slog.Logger, svc auth.Service, userIDStr string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.roles.get"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		userID, err := strconv.ParseUint(userIDStr, 10, 64)
+		if err != nil {
+			log.Error("failed to convert string", sl.Err(err))
+			authResponseErr(w, http.StatusBadRequest, "invalid user id")
+			return
+		}
+
+		roles, err := svc.GetUserRoles(userID)
+		if err != nil {
+			log.Error("failed to get roles", sl.Err(err))
+			authResponseErr(w, http.StatusInternalServerError, "failed to get roles")
+			return
+		}
+
+		userRolesResponseOK(w, userID, roles)
+	}
+}
+
+// POST /admin/users/{userID}/roles
+func NewGrantRoleHandler(log *slog.Logger, svc auth.Service, userIDStr string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.roles.grant"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		userID, err := strconv.ParseUint(userIDStr, 10, 64)
+		if err != nil {
+			log.Error("failed to convert string", sl.Err(err))
+			authResponseErr(w, http.StatusBadRequest, "invalid user id")
+			return
+		}
+
+		var req dto.GrantRoleRequest
+		if !decodeAuthRequest(log, w, r, &req) {
+			return
+		}
+
+		actor, _ := middleware.GetPrincipal(r)
+
+		if err := svc.GrantRole(*actor, userID, auth.Role(req.Role)); err != nil {
+			log.Error("failed to grant role", sl.Err(err))
+			writeRoleError(w, err)
+			return
+		}
+
+		log.Info("role granted",
+			slog.Uint64("user_id", userID),
+			slog.String("role", req.Role),
+			slog.Uint64("granted_by", actor.UserID),
+		)
+
+		roles, err := svc.GetUserRoles(userID)
+		if err != nil {
+			log.Error("failed to get roles", sl.Err(err))
+			authResponseErr(w, http.StatusInternalServerError, "failed to get roles")
+			return
+		}
+
+		userRolesResponseOK(w, userID, roles)
+	}
+}
+
+// DELETE /admin/users/{userID}/roles/{role}
+func NewRevokeRoleHandler(log *slog.Logger, svc auth.Service, userIDStr, roleStr string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.roles.revoke"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		userID, err := strconv.ParseUint(userIDStr, 10, 64)
+		if err != nil {
+			log.Error("failed to convert string", sl.Err(err))
+			authResponseErr(w, http.StatusBadRequest, "invalid user id")
+			return
+		}
+
+		role, err := auth.ParseRole(roleStr)
+		if err != nil {
+			authResponseErr(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		actor, _ := middleware.GetPrincipal(r)
+
+		if err := svc.RevokeRole(*actor, userID, role); err != nil {
+			log.Error("failed to revoke role", sl.Err(err))
+			writeRoleError(w, err)
+			return
+		}
+
+		log.Info("role revoked",
+			slog.Uint64("user_id", userID),
+			slog.String("role", roleStr),
+			slog.Uint64("revoked_by", actor.UserID),
+		)
+
+		roles, err := svc.GetUserRoles(userID)
+		if err != nil {
+			log.Error("failed to get roles", sl.Err(err))
+			authResponseErr(w, http.StatusInternalServerError, "failed to get roles")
+			return
+		}
+
+		userRolesResponseOK(w, userID, roles)
+	}
+}
+
+func writeRoleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, auth.ErrUserNotFound):
+		authResponseErr(w, http.StatusNotFound, auth.ErrUserNotFound.Error())
+	case errors.Is(err, auth.ErrRoleNotAssignable):
+		authResponseErr(w, http.StatusBadRequest, auth.ErrRoleNotAssignable.Error())
+	case errors.Is(err, auth.ErrCannotRevokeOwnRole):
+		authResponseErr(w, http.StatusConflict, auth.ErrCannotRevokeOwnRole.Error())
+	default:
+		authResponseErr(w, http.StatusInternalServerError, "failed to update roles")
+	}
+}
+
+func userRolesResponseOK(w http.ResponseWriter, userID uint64, roles []auth.Role) {
+	transport.WriteJSON(w, http.StatusOK, dto.UserRolesResponse{
+		ValidationResponse: validateResp.OK(),
+		UserID:             userID,
+		Roles:              roleNames(roles),
+	})
+}
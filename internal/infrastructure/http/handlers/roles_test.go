@@ -0,0 +1,271 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	auth "question-answer/internal/domain/users"
+	"question-answer/internal/infrastructure/http/handlers"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/handlers/mocks"
+	"question-answer/internal/infrastructure/http/middleware"
+	slogdiscard "question-answer/pkg/sl_logger/slog_discard"
+
+	"github.com/stretchr/testify/require"
+)
+
+var admin = &auth.Principal{UserID: 1, Username: "root", Roles: []auth.Role{auth.RoleAdmin, auth.RoleMember}}
+
+func TestGetUserRolesHandler(t *testing.T) {
+	cases := []struct {
+		name           string
+		userID         string
+		callService    bool
+		mockReturnErr  error
+		expectedStatus int
+	}{
+		{
+			name:           "Success",
+			userID:         "3",
+			callService:    true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid user id",
+			userID:         "three",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Storage failure",
+			userID:         "3",
+			callService:    true,
+			mockReturnErr:  errors.New("db down"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svcMock := mocks.NewAuthService(t)
+
+			if tc.callService {
+				var roles []auth.Role
+				if tc.mockReturnErr == nil {
+					roles = []auth.Role{auth.RoleModerator, auth.RoleMember}
+				}
+				svcMock.On("GetUserRoles", uint64(3)).Return(roles, tc.mockReturnErr).Once()
+			}
+
+			handler := handlers.NewGetUserRolesHandler(slogdiscard.NewDiscardLogger(), svcMock, tc.userID)
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/users/"+tc.userID+"/roles", nil)
+			req = req.WithContext(middleware.WithPrincipal(req.Context(), admin))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expectedStatus, rr.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var resp dto.UserRolesResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				require.Equal(t, uint64(3), resp.UserID)
+				require.Equal(t, []string{"moderator", "member"}, resp.Roles)
+			}
+
+			svcMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGrantRoleHandler(t *testing.T) {
+	cases := []struct {
+		name           string
+		reqBody        string
+		callService    bool
+		mockReturnErr  error
+		expectedStatus int
+	}{
+		{
+			name:           "Success",
+			reqBody:        `{"role": "moderator"}`,
+			callService:    true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Unknown role",
+			reqBody:        `{"role": "owner"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Member role",
+			reqBody:        `{"role": "member"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Missing user",
+			reqBody:        `{"role": "moderator"}`,
+			callService:    true,
+			mockReturnErr:  fmt.Errorf("storage: %w", auth.ErrUserNotFound),
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svcMock := mocks.NewAuthService(t)
+
+			if tc.callService {
+				svcMock.On("GrantRole", *admin, uint64(3), auth.RoleModerator).Return(tc.mockReturnErr).Once()
+				if tc.mockReturnErr == nil {
+					svcMock.On("GetUserRoles", uint64(3)).
+						Return([]auth.Role{auth.RoleModerator, auth.RoleMember}, nil).
+						Once()
+				}
+			}
+
+			handler := handlers.NewGrantRoleHandler(slogdiscard.NewDiscardLogger(), svcMock, "3")
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/users/3/roles", strings.NewReader(tc.reqBody))
+			req = req.WithContext(middleware.WithPrincipal(req.Context(), admin))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expectedStatus, rr.Code)
+
+			svcMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRevokeRoleHandler(t *testing.T) {
+	cases := []struct {
+		name           string
+		userID         string
+		role           string
+		callService    bool
+		mockReturnErr  error
+		expectedStatus int
+	}{
+		{
+			name:           "Success",
+			userID:         "3",
+			role:           "moderator",
+			callService:    true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Unknown role",
+			userID:         "3",
+			role:           "owner",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Member role",
+			userID:         "3",
+			role:           "member",
+			callService:    true,
+			mockReturnErr:  auth.ErrRoleNotAssignable,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Missing user",
+			userID:         "3",
+			role:           "moderator",
+			callService:    true,
+			mockReturnErr:  fmt.Errorf("storage: %w", auth.ErrUserNotFound),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Own admin role",
+			userID:         "1",
+			role:           "admin",
+			callService:    true,
+			mockReturnErr:  auth.ErrCannotRevokeOwnRole,
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svcMock := mocks.NewAuthService(t)
+
+			if tc.callService {
+				userID, err := strconv.ParseUint(tc.userID, 10, 64)
+				require.NoError(t, err)
+
+				svcMock.On("RevokeRole", *admin, userID, auth.Role(tc.role)).
+					Return(tc.mockReturnErr).
+					Once()
+				if tc.mockReturnErr == nil {
+					svcMock.On("GetUserRoles", uint64(3)).Return([]auth.Role{auth.RoleMember}, nil).Once()
+				}
+			}
+
+			handler := handlers.NewRevokeRoleHandler(slogdiscard.NewDiscardLogger(), svcMock, tc.userID, tc.role)
+
+			req := httptest.NewRequest(http.MethodDelete, "/admin/users/"+tc.userID+"/roles/"+tc.role, nil)
+			req = req.WithContext(middleware.WithPrincipal(req.Context(), admin))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expectedStatus, rr.Code)
+
+			svcMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRoleHandlersRequirePermission(t *testing.T) {
+	cases := []struct {
+		name           string
+		principal      *auth.Principal
+		expectedStatus int
+	}{
+		{
+			name:           "Anonymous",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Moderator",
+			principal:      &auth.Principal{UserID: 2, Roles: []auth.Role{auth.RoleModerator, auth.RoleMember}},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// The service mock fails the test if a handler gets through.
+			svcMock := mocks.NewAuthService(t)
+			log := slogdiscard.NewDiscardLogger()
+			guard := middleware.RequirePermission(auth.PermManageRoles)
+
+			for _, h := range []struct {
+				method  string
+				handler http.Handler
+			}{
+				{http.MethodGet, handlers.NewGetUserRolesHandler(log, svcMock, "3")},
+				{http.MethodPost, handlers.NewGrantRoleHandler(log, svcMock, "3")},
+				{http.MethodDelete, handlers.NewRevokeRoleHandler(log, svcMock, "3", "moderator")},
+			} {
+				req := httptest.NewRequest(h.method, "/admin/users/3/roles", strings.NewReader(`{"role": "moderator"}`))
+				if tc.principal != nil {
+					req = req.WithContext(middleware.WithPrincipal(req.Context(), tc.principal))
+				}
+
+				rr := httptest.NewRecorder()
+				guard(h.handler).ServeHTTP(rr, req)
+
+				require.Equal(t, tc.expectedStatus, rr.Code, h.method)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"errors"
+	"html"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"question-answer/internal/domain/qa"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/middleware"
+	"question-answer/internal/infrastructure/http/transport"
+	"question-answer/pkg/searchquery"
+	"question-answer/pkg/sl_logger/sl"
+	validateResp "question-answer/pkg/validator"
+)
+
+// snippetMarks turns the match markers of an escaped snippet into <mark>.
+var snippetMarks = strings.NewReplacer(
+	qa.SnippetMatchStart, "<mark>",
+	qa.SnippetMatchStop, "</mark>",
+)
+
+// GET /search?q=
+//
+// The query language is described at qa.ParseSearchQuery.
+func NewSearchHandler(log *slog.Logger, svc qa.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.search"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		hits, err := svc.Search(r.URL.Query().Get("q"))
+		if err != nil {
+			log.Error("failed to search", sl.Err(err))
+			var queryErr *searchquery.Error
+			if errors.As(err, &queryErr) {
+				resp := validateResp.Error(err.Error())
+				resp.Errors["column"] = strconv.Itoa(queryErr.Col)
+				transport.WriteJSON(w, http.StatusBadRequest, resp)
+				return
+			}
+			if errors.Is(err, qa.ErrInvalidSearch) {
+				writeQAError(w, err, "failed to search")
+				return
+			}
+			authResponseErr(w, http.StatusInternalServerError, "failed to search")
+			return
+		}
+
+		data := make([]dto.SearchHitResponse, 0, len(hits))
+		for _, h := range hits {
+			data = append(data, dto.SearchHitResponse{
+				QuestionID:  h.QuestionID,
+				AnswerID:    h.AnswerID,
+				Title:       h.Title,
+				SnippetHTML: snippetMarks.Replace(html.EscapeString(h.Snippet)),
+				Rank:        h.Rank,
+				CreatedAt:   h.CreatedAt,
+			})
+		}
+
+		transport.WriteJSON(w, http.StatusOK, dto.SearchResponse{
+			ValidationResponse: validateResp.OK(),
+			Data:               data,
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"question-answer/internal/domain/qa"
+	"question-answer/internal/infrastructure/http/handlers"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/handlers/mocks"
+	slogdiscard "question-answer/pkg/sl_logger/slog_discard"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchHandler(t *testing.T) {
+	svcMock := mocks.NewService(t)
+	svcMock.On("Search", "pgx pool").Return([]qa.SearchHit{
+		{QuestionID: 2, Title: "pgx pools?", Snippet: "tuning <b>\u0002pgx\u0003</b> \u0002pools\u0003", Rank: 0.6},
+		{QuestionID: 2, AnswerID: 9, Title: "pgx pools?", Snippet: "set MaxConns on the \u0002pool\u0003", Rank: 0.3},
+	}, nil).Once()
+	svcMock.On("Search", "").Return(nil, qa.ErrInvalidSearch).Once()
+	_, parseErr := qa.ParseSearchQuery("pgx is:closed")
+	svcMock.On("Search", "pgx is:closed").Return(nil, parseErr).Once()
+	svcMock.On("Search", "down").Return(nil, errors.New("db down")).Once()
+
+	handler := handlers.NewSearchHandler(slogdiscard.NewDiscardLogger(), svcMock)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/search?q=pgx+pool", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp dto.SearchResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Len(t, resp.Data, 2)
+	require.Zero(t, resp.Data[0].AnswerID)
+	require.Equal(t, "tuning &lt;b&gt;<mark>pgx</mark>&lt;/b&gt; <mark>pools</mark>", resp.Data[0].SnippetHTML)
+	require.Equal(t, uint64(9), resp.Data[1].AnswerID)
+
+	for query, status := range map[string]int{"": http.StatusBadRequest, "down": http.StatusInternalServerError} {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/search?q="+query, nil))
+		require.Equal(t, status, rr.Code, "query %q", query)
+	}
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/search?q=pgx+is:closed", nil))
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var errResp struct {
+		Errors map[string]string `json:"errors"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errResp))
+	require.Equal(t, "8", errResp.Errors["column"])
+	require.Contains(t, errResp.Errors["error"], "unknown is:closed")
+
+	svcMock.AssertExpectations(t)
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"question-answer/internal/domain/qa"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/middleware"
+	"question-answer/internal/infrastructure/http/transport"
+	"question-answer/pkg/sl_logger/sl"
+	validateResp "question-answer/pkg/validator"
+)
+
+// GET /questions/similar?text=
+func NewSimilarQuestionsHandler(log *slog.Logger, svc qa.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.questions.similar"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		similar, err := svc.SimilarQuestions(r.URL.Query().Get("text"), 0)
+		if err != nil {
+			log.Error("failed to find similar questions", sl.Err(err))
+			if errors.Is(err, qa.ErrInvalidSimilarText) {
+				writeQAError(w, err, "failed to find similar questions")
+				return
+			}
+			authResponseErr(w, http.StatusInternalServerError, "failed to find similar questions")
+			return
+		}
+
+		data := toSimilarQuestionResponses(similar)
+		if data == nil {
+			data = []dto.SimilarQuestionResponse{}
+		}
+
+		transport.WriteJSON(w, http.StatusOK, dto.SimilarQuestionsResponse{
+			ValidationResponse: validateResp.OK(),
+			Data:               data,
+		})
+	}
+}
+
+func toSimilarQuestionResponses(similar []qa.SimilarQuestion) []dto.SimilarQuestionResponse {
+	if len(similar) == 0 {
+		return nil
+	}
+
+	res := make([]dto.SimilarQuestionResponse, 0, len(similar))
+	for _, q := range similar {
+		res = append(res, dto.SimilarQuestionResponse{
+			ID:               q.ID,
+			Title:            q.Title,
+			AnswerCount:      q.AnswerCount,
+			AcceptedAnswerID: q.AcceptedAnswerID,
+			Score:            q.Score,
+		})
+	}
+	return res
+}
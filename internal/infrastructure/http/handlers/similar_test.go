@@ -0,0 +1,105 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"question-answer/internal/domain/qa"
+	auth "question-answer/internal/domain/users"
+	"question-answer/internal/infrastructure/http/handlers"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/handlers/mocks"
+	"question-answer/internal/infrastructure/http/middleware"
+	slogdiscard "question-answer/pkg/sl_logger/slog_discard"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimilarQuestionsHandler(t *testing.T) {
+	svcMock := mocks.NewService(t)
+	svcMock.On("SimilarQuestions", "pgx pool size", uint64(0)).Return([]qa.SimilarQuestion{
+		{ID: 2, Title: "pgx pool sizing", AnswerCount: 3, AcceptedAnswerID: 9, Score: 0.71},
+	}, nil).Once()
+	svcMock.On("SimilarQuestions", "nothing like it", uint64(0)).Return(nil, nil).Once()
+	svcMock.On("SimilarQuestions", "", uint64(0)).Return(nil, qa.ErrInvalidSimilarText).Once()
+
+	handler := handlers.NewSimilarQuestionsHandler(slogdiscard.NewDiscardLogger(), svcMock)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/questions/similar?text=pgx+pool+size", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp dto.SimilarQuestionsResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Equal(t, []dto.SimilarQuestionResponse{
+		{ID: 2, Title: "pgx pool sizing", AnswerCount: 3, AcceptedAnswerID: 9, Score: 0.71},
+	}, resp.Data)
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/questions/similar?text=nothing+like+it", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	var empty dto.SimilarQuestionsResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &empty))
+	require.NotNil(t, empty.Data)
+	require.Empty(t, empty.Data)
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/questions/similar", nil))
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestAddQuestionHandlerSuggestsSimilar(t *testing.T) {
+	asker := &auth.Principal{UserID: 4}
+
+	cases := []struct {
+		name            string
+		target          string
+		lookup          bool
+		lookupErr       error
+		expectedSimilar int
+	}{
+		{name: "Not asked", target: "/questions"},
+		{name: "Asked", target: "/questions?similar=true", lookup: true, expectedSimilar: 1},
+		{name: "Lookup fails", target: "/questions?similar=true", lookup: true, lookupErr: errors.New("db down")},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svcMock := mocks.NewService(t)
+			svcMock.On("CreateQuestion", qa.Question{UserID: 4, Title: "pgx pool size?"}).
+				Return(&qa.Question{ID: 7, UserID: 4, Title: "pgx pool size?"}, nil).
+				Once()
+			if tc.lookup {
+				var similar []qa.SimilarQuestion
+				if tc.lookupErr == nil {
+					similar = []qa.SimilarQuestion{{ID: 2, Title: "pgx pool sizing", Score: 0.71}}
+				}
+				svcMock.On("SimilarQuestions", "pgx pool size?", uint64(7)).
+					Return(similar, tc.lookupErr).
+					Once()
+			}
+
+			handler := handlers.NewAddQuestionHandler(slogdiscard.NewDiscardLogger(), svcMock)
+
+			req := httptest.NewRequest(http.MethodPost, tc.target, bytes.NewReader([]byte(`{"title": "pgx pool size?"}`)))
+			req = req.WithContext(middleware.WithPrincipal(req.Context(), asker))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			require.Equal(t, http.StatusOK, rr.Code)
+
+			var resp dto.AddQuestionResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.Equal(t, uint64(7), resp.ID)
+			require.Len(t, resp.Similar, tc.expectedSimilar)
+
+			svcMock.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"question-answer/internal/domain/qa"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/middleware"
+	"question-answer/internal/infrastructure/http/transport"
+	"question-answer/pkg/sl_logger/sl"
+	validateResp "question-answer/pkg/validator"
+)
+
+// GET /tags
+func NewListTagsHandler(log *slog.Logger, svc qa.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.tags.list"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		tags, err := svc.ListTags()
+		if err != nil {
+			log.Error("failed to list tags", sl.Err(err))
+			authResponseErr(w, http.StatusInternalServerError, "failed to list tags")
+			return
+		}
+
+		data := make([]dto.TagResponse, 0, len(tags))
+		for _, t := range tags {
+			data = append(data, dto.TagResponse{
+				Name:     t.Name,
+				Count:    t.Count,
+				Synonyms: t.Synonyms,
+			})
+		}
+
+		transport.WriteJSON(w, http.StatusOK, dto.ListTagsResponse{
+			ValidationResponse: validateResp.OK(),
+			Data:               data,
+		})
+	}
+}
+
+// POST /tags/{tag}/synonyms
+func NewAddTagSynonymHandler(log *slog.Logger, svc qa.Service, tag string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.tags.addSynonym"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		var req dto.AddTagSynonymRequest
+		if !decodeAuthRequest(log, w, r, &req) {
+			return
+		}
+
+		if err := svc.AddTagSynonym(tag, req.Synonym, actorFromRequest(r)); err != nil {
+			log.Error("failed to add tag synonym", sl.Err(err))
+			writeQAError(w, err, "failed to add tag synonym")
+			return
+		}
+
+		log.Info("tag synonym added",
+			slog.String("tag", tag),
+			slog.String("synonym", req.Synonym),
+		)
+
+		transport.WriteJSON(w, http.StatusCreated, validateResp.OK())
+	}
+}
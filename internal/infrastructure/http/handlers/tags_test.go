@@ -0,0 +1,129 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"question-answer/internal/domain/qa"
+	auth "question-answer/internal/domain/users"
+	"question-answer/internal/infrastructure/http/handlers"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/handlers/mocks"
+	"question-answer/internal/infrastructure/http/middleware"
+	slogdiscard "question-answer/pkg/sl_logger/slog_discard"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListTagsHandler(t *testing.T) {
+	svcMock := mocks.NewService(t)
+
+	svcMock.On("ListTags").Return([]qa.Tag{
+		{ID: 1, Name: "go", Count: 12, Synonyms: []string{"golang"}},
+		{ID: 2, Name: "postgres", Count: 3},
+	}, nil).Once()
+
+	handler := handlers.NewListTagsHandler(slogdiscard.NewDiscardLogger(), svcMock)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/tags", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp dto.ListTagsResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Len(t, resp.Data, 2)
+	require.Equal(t, "go", resp.Data[0].Name)
+	require.Equal(t, int64(12), resp.Data[0].Count)
+	require.Equal(t, []string{"golang"}, resp.Data[0].Synonyms)
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestGetQuestionHandlerTagFilter(t *testing.T) {
+	svcMock := mocks.NewService(t)
+
+	svcMock.On("GetAllQuestions", qa.QuestionFilter{Tags: []string{"go", "postgres"}}, qa.PageRequest{}).
+		Return(&qa.QuestionPage{Questions: []qa.Question{{ID: 1, Title: "pgx pools?", Tags: []string{"go", "postgres"}}}}, nil).
+		Once()
+
+	handler := handlers.NewGetQuestionHandler(slogdiscard.NewDiscardLogger(), svcMock)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/questions?tag=go&tag=postgres", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp dto.GetQuestionResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Len(t, resp.Data, 1)
+	require.Equal(t, []string{"go", "postgres"}, resp.Data[0].Tags)
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestAddQuestionHandlerTags(t *testing.T) {
+	author := &auth.Principal{UserID: 4, Username: "asker"}
+
+	cases := []struct {
+		name           string
+		reqBody        string
+		callService    bool
+		mockReturnErr  error
+		expectedStatus int
+	}{
+		{
+			name:           "Tags passed through",
+			reqBody:        `{"title": "pgx pools?", "tags": ["Go", "postgres"]}`,
+			callService:    true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Too many tags",
+			reqBody:        `{"title": "pgx pools?", "tags": ["a", "b", "c", "d", "e", "f"]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid tag",
+			reqBody:        `{"title": "pgx pools?", "tags": ["Go", "postgres"]}`,
+			callService:    true,
+			mockReturnErr:  qa.ErrInvalidTag,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svcMock := mocks.NewService(t)
+
+			if tc.callService {
+				var created *qa.Question
+				if tc.mockReturnErr == nil {
+					created = &qa.Question{ID: 1, UserID: 4, Title: "pgx pools?", Tags: []string{"go", "postgres"}}
+				}
+				svcMock.On("CreateQuestion", qa.Question{UserID: 4, Title: "pgx pools?", Tags: []string{"Go", "postgres"}}).
+					Return(created, tc.mockReturnErr).
+					Once()
+			}
+
+			handler := handlers.NewAddQuestionHandler(slogdiscard.NewDiscardLogger(), svcMock)
+
+			req := httptest.NewRequest(http.MethodPost, "/questions", bytes.NewReader([]byte(tc.reqBody)))
+			req = req.WithContext(middleware.WithPrincipal(req.Context(), author))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expectedStatus, rr.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var resp dto.AddQuestionResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				require.Equal(t, []string{"go", "postgres"}, resp.Tags)
+			}
+
+			svcMock.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"question-answer/internal/domain/qa"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/middleware"
+	"question-answer/internal/infrastructure/http/transport"
+	"question-answer/pkg/markdown"
+	"question-answer/pkg/sl_logger/sl"
+	validateResp "question-answer/pkg/validator"
+)
+
+// POST /questions/{questionID}/restore
+func NewRestoreQuestionHandler(log *slog.Logger, svc qa.Service, idStr string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.question.restore"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		id, err := strconv.ParseUint(idStr, 10, 64)
+		if err != nil {
+			log.Error("failed to convert string", sl.Err(err))
+			authResponseErr(w, http.StatusBadRequest, "invalid question id")
+			return
+		}
+
+		question, err := svc.RestoreQuestion(id, actorFromRequest(r))
+		if err != nil {
+			log.Error("failed to restore question", sl.Err(err))
+			writeQAError(w, err, "failed to restore question")
+			return
+		}
+
+		log.Info("question restored", slog.Uint64("question_id", id))
+
+		addQuestionResponseOK(w, *question)
+	}
+}
+
+// POST /answers/{answerID}/restore
+func NewRestoreAnswerHandler(log *slog.Logger, svc qa.Service, idStr string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.answer.restore"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		id, err := strconv.ParseUint(idStr, 10, 64)
+		if err != nil {
+			log.Error("failed to convert string", sl.Err(err))
+			authResponseErr(w, http.StatusBadRequest, "invalid answer id")
+			return
+		}
+
+		answer, err := svc.RestoreAnswer(id, actorFromRequest(r))
+		if err != nil {
+			log.Error("failed to restore answer", sl.Err(err))
+			writeQAError(w, err, "failed to restore answer")
+			return
+		}
+
+		log.Info("answer restored", slog.Uint64("answer_id", id))
+
+		getAnswerResponseOK(w, *answer)
+	}
+}
+
+// GET /admin/trash
+func NewListTrashHandler(log *slog.Logger, svc qa.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.trash.list"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		trash, err := svc.ListTrash(actorFromRequest(r))
+		if err != nil {
+			log.Error("failed to list trash", sl.Err(err))
+			writeQAError(w, err, "failed to list trash")
+			return
+		}
+
+		questions := make([]dto.QuestionResponse, 0, len(trash.Questions))
+		for _, q := range trash.Questions {
+			questions = append(questions, dto.QuestionResponse{
+				ID:               q.ID,
+				UserID:           q.UserID,
+				Title:            q.Title,
+				Body:             q.Body,
+				BodyHTML:         markdown.Render(q.Body),
+				CreatedAt:        q.CreatedAt,
+				AcceptedAnswerID: q.AcceptedAnswerID,
+				Score:            q.Score,
+				DeletedAt:        q.DeletedAt,
+			})
+		}
+
+		transport.WriteJSON(w, http.StatusOK, dto.TrashResponse{
+			ValidationResponse: validateResp.OK(),
+			Questions:          questions,
+			Answers:            toAnswerResponses(trash.Answers),
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"question-answer/internal/domain/qa"
+	auth "question-answer/internal/domain/users"
+	"question-answer/internal/infrastructure/http/handlers"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/handlers/mocks"
+	"question-answer/internal/infrastructure/http/middleware"
+	slogdiscard "question-answer/pkg/sl_logger/slog_discard"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestoreAnswerHandler(t *testing.T) {
+	author := &auth.Principal{UserID: 3, Username: "author"}
+
+	cases := []struct {
+		name           string
+		mockReturnA    *qa.Answer
+		mockReturnErr  error
+		expectedStatus int
+	}{
+		{
+			name:           "Success",
+			mockReturnA:    &qa.Answer{ID: 9, QuestionID: 2, UserID: 3, Text: "restored"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Question still deleted",
+			mockReturnErr:  fmt.Errorf("storage: %w", qa.ErrQuestionDeleted),
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "Not in trash",
+			mockReturnErr:  fmt.Errorf("storage: %w", qa.ErrAnswerNotFound),
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svcMock := mocks.NewService(t)
+
+			svcMock.On("RestoreAnswer", uint64(9), qa.Actor{UserID: author.UserID}).
+				Return(tc.mockReturnA, tc.mockReturnErr).
+				Once()
+
+			handler := handlers.NewRestoreAnswerHandler(slogdiscard.NewDiscardLogger(), svcMock, "9")
+
+			req := httptest.NewRequest(http.MethodPost, "/answers/9/restore", nil)
+			req = req.WithContext(middleware.WithPrincipal(req.Context(), author))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expectedStatus, rr.Code)
+
+			svcMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListTrashHandler(t *testing.T) {
+	deletedAt := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+	moderator := &auth.Principal{UserID: 1, Roles: []auth.Role{auth.RoleModerator}}
+
+	svcMock := mocks.NewService(t)
+	svcMock.On("ListTrash", qa.Actor{UserID: 1, Privileged: true}).Return(&qa.Trash{
+		Questions: []qa.Question{{ID: 2, Title: "gone", DeletedAt: &deletedAt}},
+		Answers:   []qa.Answer{{ID: 9, QuestionID: 2, Text: "gone too", DeletedAt: &deletedAt}},
+	}, nil).Once()
+
+	handler := handlers.NewListTrashHandler(slogdiscard.NewDiscardLogger(), svcMock)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/trash", nil)
+	req = req.WithContext(middleware.WithPrincipal(req.Context(), moderator))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp dto.TrashResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Len(t, resp.Questions, 1)
+	require.Equal(t, deletedAt, *resp.Questions[0].DeletedAt)
+	require.Len(t, resp.Answers, 1)
+	require.Equal(t, deletedAt, *resp.Answers[0].DeletedAt)
+
+	svcMock.AssertExpectations(t)
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	auth "question-answer/internal/domain/users"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/middleware"
+	"question-answer/internal/infrastructure/http/transport"
+	"question-answer/pkg/sl_logger/sl"
+	validateResp "question-answer/pkg/validator"
+)
+
+// POST /auth/2fa/enroll
+func NewEnrollTOTPHandler(log *slog.Logger, svc auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.twofactor.enroll"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		p, _ := middleware.GetPrincipal(r)
+
+		enrollment, err := svc.EnrollTOTP(*p)
+		if err != nil {
+			log.Error("failed to enroll totp", sl.Err(err))
+			writeTwoFactorError(w, err)
+			return
+		}
+
+		log.Info("totp enrollment started", slog.Uint64("user_id", p.UserID))
+
+		transport.WriteJSON(w, http.StatusOK, dto.TOTPEnrollResponse{
+			ValidationResponse: validateResp.OK(),
+			Secret:             enrollment.Secret,
+			URI:                enrollment.URI,
+		})
+	}
+}
+
+// POST /auth/2fa/confirm
+func NewConfirmTOTPHandler(log *slog.Logger, svc auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.twofactor.confirm"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		var req dto.TOTPCodeRequest
+		if !decodeAuthRequest(log, w, r, &req) {
+			return
+		}
+
+		p, _ := middleware.GetPrincipal(r)
+
+		codes, err := svc.ConfirmTOTP(*p, req.Code)
+		if err != nil {
+			log.Error("failed to confirm totp", sl.Err(err))
+			writeTwoFactorError(w, err)
+			return
+		}
+
+		log.Info("totp enabled", slog.Uint64("user_id", p.UserID))
+
+		transport.WriteJSON(w, http.StatusOK, dto.TOTPConfirmResponse{
+			ValidationResponse: validateResp.OK(),
+			RecoveryCodes:      codes,
+		})
+	}
+}
+
+// POST /auth/2fa/disable
+func NewDisableTOTPHandler(log *slog.Logger, svc auth.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.twofactor.disable"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		var req dto.DisableTOTPRequest
+		if !decodeAuthRequest(log, w, r, &req) {
+			return
+		}
+
+		p, _ := middleware.GetPrincipal(r)
+
+		if err := svc.DisableTOTP(*p, req.Code, req.RecoveryCode); err != nil {
+			log.Error("failed to disable totp", sl.Err(err))
+			writeTwoFactorError(w, err)
+			return
+		}
+
+		log.Info("totp disabled", slog.Uint64("user_id", p.UserID))
+
+		transport.WriteJSON(w, http.StatusOK, validateResp.OK())
+	}
+}
+
+func writeTwoFactorError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, auth.ErrInvalidOTP):
+		authResponseErr(w, http.StatusBadRequest, auth.ErrInvalidOTP.Error())
+	case errors.Is(err, auth.ErrOTPRequired):
+		authResponseErr(w, http.StatusBadRequest, auth.ErrOTPRequired.Error())
+	case errors.Is(err, auth.ErrTOTPAlreadyEnabled):
+		authResponseErr(w, http.StatusConflict, auth.ErrTOTPAlreadyEnabled.Error())
+	case errors.Is(err, auth.ErrTOTPNotEnrolled):
+		authResponseErr(w, http.StatusConflict, auth.ErrTOTPNotEnrolled.Error())
+	case errors.Is(err, auth.ErrTOTPNotEnabled):
+		authResponseErr(w, http.StatusConflict, auth.ErrTOTPNotEnabled.Error())
+	default:
+		authResponseErr(w, http.StatusInternalServerError, "failed to update two-factor settings")
+	}
+}
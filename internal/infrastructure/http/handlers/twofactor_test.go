@@ -0,0 +1,303 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	auth "question-answer/internal/domain/users"
+	"question-answer/internal/infrastructure/http/handlers"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/handlers/mocks"
+	"question-answer/internal/infrastructure/http/middleware"
+	slogdiscard "question-answer/pkg/sl_logger/slog_discard"
+
+	"github.com/stretchr/testify/require"
+)
+
+var totpUser = &auth.Principal{UserID: 5, Username: "gopher", Roles: []auth.Role{auth.RoleMember}, SessionID: "s1"}
+
+func TestEnrollTOTPHandler(t *testing.T) {
+	cases := []struct {
+		name           string
+		mockReturnE    *auth.TOTPEnrollment
+		mockReturnErr  error
+		expectedStatus int
+	}{
+		{
+			name:           "Success",
+			mockReturnE:    &auth.TOTPEnrollment{Secret: "JBSWY3DPEHPK3PXP", URI: "otpauth://totp/qa:gopher"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Already enabled",
+			mockReturnErr:  fmt.Errorf("enroll: %w", auth.ErrTOTPAlreadyEnabled),
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "Storage failure",
+			mockReturnErr:  errors.New("db down"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svcMock := mocks.NewAuthService(t)
+			svcMock.On("EnrollTOTP", *totpUser).Return(tc.mockReturnE, tc.mockReturnErr).Once()
+
+			handler := handlers.NewEnrollTOTPHandler(slogdiscard.NewDiscardLogger(), svcMock)
+
+			req := httptest.NewRequest(http.MethodPost, "/auth/2fa/enroll", nil)
+			req = req.WithContext(middleware.WithPrincipal(req.Context(), totpUser))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expectedStatus, rr.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var resp dto.TOTPEnrollResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				require.Equal(t, tc.mockReturnE.Secret, resp.Secret)
+				require.Equal(t, tc.mockReturnE.URI, resp.URI)
+			}
+
+			svcMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestConfirmTOTPHandler(t *testing.T) {
+	cases := []struct {
+		name           string
+		reqBody        string
+		callService    bool
+		mockReturnErr  error
+		expectedStatus int
+	}{
+		{
+			name:           "Success",
+			reqBody:        `{"code": "123456"}`,
+			callService:    true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Malformed code",
+			reqBody:        `{"code": "12ab"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Wrong code",
+			reqBody:        `{"code": "123456"}`,
+			callService:    true,
+			mockReturnErr:  fmt.Errorf("confirm: %w", auth.ErrInvalidOTP),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Not enrolled",
+			reqBody:        `{"code": "123456"}`,
+			callService:    true,
+			mockReturnErr:  auth.ErrTOTPNotEnrolled,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "Already enabled",
+			reqBody:        `{"code": "123456"}`,
+			callService:    true,
+			mockReturnErr:  auth.ErrTOTPAlreadyEnabled,
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svcMock := mocks.NewAuthService(t)
+
+			codes := []string{"ABCDE-FGHJK", "LMNPQ-RSTUV"}
+			if tc.callService {
+				var ret []string
+				if tc.mockReturnErr == nil {
+					ret = codes
+				}
+				svcMock.On("ConfirmTOTP", *totpUser, "123456").Return(ret, tc.mockReturnErr).Once()
+			}
+
+			handler := handlers.NewConfirmTOTPHandler(slogdiscard.NewDiscardLogger(), svcMock)
+
+			req := httptest.NewRequest(http.MethodPost, "/auth/2fa/confirm", strings.NewReader(tc.reqBody))
+			req = req.WithContext(middleware.WithPrincipal(req.Context(), totpUser))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expectedStatus, rr.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var resp dto.TOTPConfirmResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				require.Equal(t, codes, resp.RecoveryCodes)
+			}
+
+			svcMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDisableTOTPHandler(t *testing.T) {
+	cases := []struct {
+		name             string
+		reqBody          string
+		callService      bool
+		mockCode         string
+		mockRecoveryCode string
+		mockReturnErr    error
+		expectedStatus   int
+	}{
+		{
+			name:           "Success with code",
+			reqBody:        `{"code": "123456"}`,
+			callService:    true,
+			mockCode:       "123456",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:             "Success with recovery code",
+			reqBody:          `{"recovery_code": "ABCDE-FGHJK"}`,
+			callService:      true,
+			mockRecoveryCode: "ABCDE-FGHJK",
+			expectedStatus:   http.StatusOK,
+		},
+		{
+			name:           "Neither code",
+			reqBody:        `{}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:             "Used recovery code",
+			reqBody:          `{"recovery_code": "ABCDE-FGHJK"}`,
+			callService:      true,
+			mockRecoveryCode: "ABCDE-FGHJK",
+			mockReturnErr:    fmt.Errorf("disable: %w", auth.ErrInvalidOTP),
+			expectedStatus:   http.StatusBadRequest,
+		},
+		{
+			name:           "Not enabled",
+			reqBody:        `{"code": "123456"}`,
+			callService:    true,
+			mockCode:       "123456",
+			mockReturnErr:  auth.ErrTOTPNotEnabled,
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svcMock := mocks.NewAuthService(t)
+
+			if tc.callService {
+				svcMock.On("DisableTOTP", *totpUser, tc.mockCode, tc.mockRecoveryCode).
+					Return(tc.mockReturnErr).
+					Once()
+			}
+
+			handler := handlers.NewDisableTOTPHandler(slogdiscard.NewDiscardLogger(), svcMock)
+
+			req := httptest.NewRequest(http.MethodPost, "/auth/2fa/disable", strings.NewReader(tc.reqBody))
+			req = req.WithContext(middleware.WithPrincipal(req.Context(), totpUser))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expectedStatus, rr.Code)
+
+			svcMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLoginHandlerSecondFactor(t *testing.T) {
+	cases := []struct {
+		name             string
+		reqBody          string
+		callService      bool
+		mockOTP          string
+		mockRecoveryCode string
+		mockReturnErr    error
+		expectedStatus   int
+		expectedRequired bool
+	}{
+		{
+			name:             "Code required",
+			reqBody:          `{"username": "gopher", "password": "s3cret-pass"}`,
+			callService:      true,
+			mockReturnErr:    fmt.Errorf("login: %w", auth.ErrOTPRequired),
+			expectedStatus:   http.StatusUnauthorized,
+			expectedRequired: true,
+		},
+		{
+			name:           "Malformed code",
+			reqBody:        `{"username": "gopher", "password": "s3cret-pass", "otp": "12"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Wrong code",
+			reqBody:        `{"username": "gopher", "password": "s3cret-pass", "otp": "123456"}`,
+			callService:    true,
+			mockOTP:        "123456",
+			mockReturnErr:  fmt.Errorf("login: %w", auth.ErrInvalidOTP),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:             "Recovery code",
+			reqBody:          `{"username": "gopher", "password": "s3cret-pass", "recovery_code": "ABCDE-FGHJK"}`,
+			callService:      true,
+			mockRecoveryCode: "ABCDE-FGHJK",
+			expectedStatus:   http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svcMock := mocks.NewAuthService(t)
+
+			if tc.callService {
+				var (
+					user   *auth.User
+					tokens *auth.Tokens
+				)
+				if tc.mockReturnErr == nil {
+					user = &auth.User{ID: 5, Username: "gopher"}
+					tokens = &auth.Tokens{AccessToken: "access", RefreshToken: "refresh"}
+				}
+				svcMock.On("Login", auth.LoginInput{
+					Username:     "gopher",
+					Password:     "s3cret-pass",
+					OTP:          tc.mockOTP,
+					RecoveryCode: tc.mockRecoveryCode,
+					Client:       auth.Client{IP: "192.0.2.1"},
+				}).Return(user, tokens, tc.mockReturnErr).Once()
+			}
+
+			handler := handlers.NewLoginHandler(slogdiscard.NewDiscardLogger(), svcMock)
+
+			req := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(tc.reqBody))
+			req.RemoteAddr = "192.0.2.1:51234"
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expectedStatus, rr.Code)
+
+			var resp dto.LoginResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.Equal(t, tc.expectedRequired, resp.OTPRequired)
+
+			svcMock.AssertExpectations(t)
+		})
+	}
+}
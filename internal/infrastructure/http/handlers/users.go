@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"question-answer/internal/domain/qa"
+	auth "question-answer/internal/domain/users"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/middleware"
+	"question-answer/internal/infrastructure/http/transport"
+	"question-answer/pkg/sl_logger/sl"
+	validateResp "question-answer/pkg/validator"
+)
+
+// GET /users/{userID}
+func NewGetUserHandler(log *slog.Logger, users auth.Service, svc qa.Service, userIDStr string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.users.get"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		user, ok := lookupUser(log, w, users, userIDStr)
+		if !ok {
+			return
+		}
+
+		reputation, err := svc.GetReputation(user.ID)
+		if err != nil {
+			log.Error("failed to get reputation", sl.Err(err))
+			authResponseErr(w, http.StatusInternalServerError, "failed to get user")
+			return
+		}
+
+		profile := toUserResponse(user)
+		profile.Reputation = &reputation
+
+		transport.WriteJSON(w, http.StatusOK, dto.UserProfileResponse{
+			ValidationResponse: validateResp.OK(),
+			User:               profile,
+		})
+	}
+}
+
+// GET /users/{userID}/questions
+func NewGetUserQuestionsHandler(log *slog.Logger, users auth.Service, svc qa.Service, userIDStr string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.users.questions"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		user, ok := lookupUser(log, w, users, userIDStr)
+		if !ok {
+			return
+		}
+
+		questions, err := svc.GetQuestionsByUser(user.ID)
+		if err != nil {
+			log.Error("failed to get user questions", sl.Err(err))
+			getQuestionResponseErr(w, "failed to get user questions")
+			return
+		}
+
+		getQuestionResponseOK(w, &qa.QuestionPage{Questions: questions})
+	}
+}
+
+// GET /users/{userID}/answers
+func NewGetUserAnswersHandler(log *slog.Logger, users auth.Service, svc qa.Service, userIDStr string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.users.answers"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		user, ok := lookupUser(log, w, users, userIDStr)
+		if !ok {
+			return
+		}
+
+		answers, err := svc.GetAnswersByUser(user.ID)
+		if err != nil {
+			log.Error("failed to get user answers", sl.Err(err))
+			transport.WriteJSON(w, http.StatusBadRequest, dto.GetAnswersResponse{
+				ValidationResponse: validateResp.Error("failed to get user answers"),
+			})
+			return
+		}
+
+		transport.WriteJSON(w, http.StatusOK, dto.GetAnswersResponse{
+			ValidationResponse: validateResp.OK(),
+			Data:               toAnswerResponses(answers),
+		})
+	}
+}
+
+// lookupUser parses the user id and loads the user. It writes the error
+// response itself and reports whether the handler may continue.
+func lookupUser(log *slog.Logger, w http.ResponseWriter, users auth.Service, userIDStr string) (*auth.User, bool) {
+	userID, err := strconv.ParseUint(userIDStr, 10, 64)
+	if err != nil {
+		log.Error("failed to convert string", sl.Err(err))
+		authResponseErr(w, http.StatusBadRequest, "invalid user id")
+		return nil, false
+	}
+
+	user, err := users.GetUser(userID)
+	if errors.Is(err, auth.ErrUserNotFound) {
+		authResponseErr(w, http.StatusNotFound, auth.ErrUserNotFound.Error())
+		return nil, false
+	}
+	if err != nil {
+		log.Error("failed to get user", sl.Err(err))
+		authResponseErr(w, http.StatusInternalServerError, "failed to get user")
+		return nil, false
+	}
+
+	return user, true
+}
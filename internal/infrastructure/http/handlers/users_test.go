@@ -0,0 +1,252 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"question-answer/internal/domain/qa"
+	auth "question-answer/internal/domain/users"
+	"question-answer/internal/infrastructure/http/handlers"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/handlers/mocks"
+	slogdiscard "question-answer/pkg/sl_logger/slog_discard"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetUserHandler(t *testing.T) {
+	gopher := &auth.User{ID: 3, Username: "gopher", Roles: []auth.Role{auth.RoleMember}, CreatedAt: time.Now()}
+
+	cases := []struct {
+		name           string
+		userID         string
+		callUsers      bool
+		mockReturnUser *auth.User
+		mockUserErr    error
+		callService    bool
+		mockReputation int64
+		mockRepErr     error
+		expectedStatus int
+	}{
+		{
+			name:           "Success",
+			userID:         "3",
+			callUsers:      true,
+			mockReturnUser: gopher,
+			callService:    true,
+			mockReputation: 25,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid user id",
+			userID:         "three",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Missing user",
+			userID:         "3",
+			callUsers:      true,
+			mockUserErr:    fmt.Errorf("storage: %w", auth.ErrUserNotFound),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Reputation failure",
+			userID:         "3",
+			callUsers:      true,
+			mockReturnUser: gopher,
+			callService:    true,
+			mockRepErr:     errors.New("db down"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			usersMock := mocks.NewAuthService(t)
+			svcMock := mocks.NewService(t)
+
+			if tc.callUsers {
+				usersMock.On("GetUser", uint64(3)).Return(tc.mockReturnUser, tc.mockUserErr).Once()
+			}
+			if tc.callService {
+				svcMock.On("GetReputation", uint64(3)).Return(tc.mockReputation, tc.mockRepErr).Once()
+			}
+
+			handler := handlers.NewGetUserHandler(slogdiscard.NewDiscardLogger(), usersMock, svcMock, tc.userID)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/users/"+tc.userID, nil))
+
+			require.Equal(t, tc.expectedStatus, rr.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var resp dto.UserProfileResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				require.Equal(t, "gopher", resp.User.Username)
+				require.Equal(t, tc.mockReputation, *resp.User.Reputation)
+			}
+
+			usersMock.AssertExpectations(t)
+			svcMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetUserQuestionsHandler(t *testing.T) {
+	cases := []struct {
+		name           string
+		userID         string
+		callUsers      bool
+		mockUserErr    error
+		callService    bool
+		mockQuestions  []qa.Question
+		mockErr        error
+		expectedStatus int
+	}{
+		{
+			name:           "Success",
+			userID:         "3",
+			callUsers:      true,
+			callService:    true,
+			mockQuestions:  []qa.Question{{ID: 1, UserID: 3, Title: "why?"}, {ID: 2, UserID: 3, Title: "how?"}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid user id",
+			userID:         "-1",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Missing user",
+			userID:         "3",
+			callUsers:      true,
+			mockUserErr:    auth.ErrUserNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Service failure",
+			userID:         "3",
+			callUsers:      true,
+			callService:    true,
+			mockErr:        errors.New("db down"),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			usersMock := mocks.NewAuthService(t)
+			svcMock := mocks.NewService(t)
+
+			if tc.callUsers {
+				var user *auth.User
+				if tc.mockUserErr == nil {
+					user = &auth.User{ID: 3, Username: "gopher"}
+				}
+				usersMock.On("GetUser", uint64(3)).Return(user, tc.mockUserErr).Once()
+			}
+			if tc.callService {
+				svcMock.On("GetQuestionsByUser", uint64(3)).Return(tc.mockQuestions, tc.mockErr).Once()
+			}
+
+			handler := handlers.NewGetUserQuestionsHandler(slogdiscard.NewDiscardLogger(), usersMock, svcMock, tc.userID)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/users/"+tc.userID+"/questions", nil))
+
+			require.Equal(t, tc.expectedStatus, rr.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var resp dto.GetQuestionResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				require.Len(t, resp.Data, len(tc.mockQuestions))
+				require.Empty(t, resp.NextCursor)
+			}
+
+			usersMock.AssertExpectations(t)
+			svcMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetUserAnswersHandler(t *testing.T) {
+	cases := []struct {
+		name           string
+		userID         string
+		callUsers      bool
+		mockUserErr    error
+		callService    bool
+		mockAnswers    []qa.Answer
+		mockErr        error
+		expectedStatus int
+	}{
+		{
+			name:           "Success",
+			userID:         "3",
+			callUsers:      true,
+			callService:    true,
+			mockAnswers:    []qa.Answer{{ID: 8, QuestionID: 1, UserID: 3, Text: "because"}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid user id",
+			userID:         "",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Missing user",
+			userID:         "3",
+			callUsers:      true,
+			mockUserErr:    auth.ErrUserNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Service failure",
+			userID:         "3",
+			callUsers:      true,
+			callService:    true,
+			mockErr:        errors.New("db down"),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			usersMock := mocks.NewAuthService(t)
+			svcMock := mocks.NewService(t)
+
+			if tc.callUsers {
+				var user *auth.User
+				if tc.mockUserErr == nil {
+					user = &auth.User{ID: 3, Username: "gopher"}
+				}
+				usersMock.On("GetUser", uint64(3)).Return(user, tc.mockUserErr).Once()
+			}
+			if tc.callService {
+				svcMock.On("GetAnswersByUser", uint64(3)).Return(tc.mockAnswers, tc.mockErr).Once()
+			}
+
+			handler := handlers.NewGetUserAnswersHandler(slogdiscard.NewDiscardLogger(), usersMock, svcMock, tc.userID)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/users/3/answers", nil))
+
+			require.Equal(t, tc.expectedStatus, rr.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var resp dto.GetAnswersResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				require.Len(t, resp.Data, 1)
+				require.Equal(t, uint64(8), resp.Data[0].ID)
+			}
+
+			usersMock.AssertExpectations(t)
+			svcMock.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"question-answer/internal/domain/qa"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/middleware"
+	"question-answer/internal/infrastructure/http/transport"
+	"question-answer/pkg/sl_logger/sl"
+	validateResp "question-answer/pkg/validator"
+)
+
+// POST /answers/{answerID}/vote and /questions/{questionID}/vote
+func NewVoteHandler(log *slog.Logger, svc qa.Service, onAnswer bool, idStr string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.votes.vote"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		id, err := strconv.ParseUint(idStr, 10, 64)
+		if err != nil {
+			log.Error("failed to convert string", sl.Err(err))
+			authResponseErr(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		var req dto.VoteRequest
+		if !decodeAuthRequest(log, w, r, &req) {
+			return
+		}
+
+		v, err := qa.ParseVote(req.Value)
+		if err != nil {
+			writeQAError(w, err, err.Error())
+			return
+		}
+
+		var res dto.VoteResponse
+		if onAnswer {
+			var answer *qa.Answer
+			if answer, err = svc.Vote(id, actorFromRequest(r), v); err == nil {
+				res = answerVoteResponse(*answer)
+			}
+		} else {
+			var question *qa.Question
+			if question, err = svc.VoteQuestion(id, actorFromRequest(r), v); err == nil {
+				res = questionVoteResponse(*question)
+			}
+		}
+		if err != nil {
+			log.Error("failed to vote", sl.Err(err))
+			writeQAError(w, err, "failed to vote")
+			return
+		}
+
+		log.Info("voted", slog.Uint64("id", id), slog.Bool("on_answer", onAnswer), slog.Int64("score", res.Score))
+
+		transport.WriteJSON(w, http.StatusOK, res)
+	}
+}
+
+// DELETE /answers/{answerID}/vote and /questions/{questionID}/vote
+func NewUnvoteHandler(log *slog.Logger, svc qa.Service, onAnswer bool, idStr string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		const op = "handlers.votes.unvote"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetRequestID(r)),
+		)
+
+		id, err := strconv.ParseUint(idStr, 10, 64)
+		if err != nil {
+			log.Error("failed to convert string", sl.Err(err))
+			authResponseErr(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		var res dto.VoteResponse
+		if onAnswer {
+			var answer *qa.Answer
+			if answer, err = svc.Unvote(id, actorFromRequest(r)); err == nil {
+				res = answerVoteResponse(*answer)
+			}
+		} else {
+			var question *qa.Question
+			if question, err = svc.UnvoteQuestion(id, actorFromRequest(r)); err == nil {
+				res = questionVoteResponse(*question)
+			}
+		}
+		if err != nil {
+			log.Error("failed to remove vote", sl.Err(err))
+			writeQAError(w, err, "failed to remove vote")
+			return
+		}
+
+		log.Info("vote removed", slog.Uint64("id", id), slog.Bool("on_answer", onAnswer), slog.Int64("score", res.Score))
+
+		transport.WriteJSON(w, http.StatusOK, res)
+	}
+}
+
+func answerVoteResponse(a qa.Answer) dto.VoteResponse {
+	return dto.VoteResponse{
+		ValidationResponse: validateResp.OK(),
+		AnswerID:           a.ID,
+		Score:              a.Score,
+		MyVote:             a.ViewerVote.String(),
+	}
+}
+
+func questionVoteResponse(q qa.Question) dto.VoteResponse {
+	return dto.VoteResponse{
+		ValidationResponse: validateResp.OK(),
+		QuestionID:         q.ID,
+		Score:              q.Score,
+		MyVote:             q.ViewerVote.String(),
+	}
+}
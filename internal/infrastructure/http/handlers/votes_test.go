@@ -0,0 +1,155 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"question-answer/internal/domain/qa"
+	auth "question-answer/internal/domain/users"
+	"question-answer/internal/infrastructure/http/handlers"
+	dto "question-answer/internal/infrastructure/http/handlers/dto"
+	"question-answer/internal/infrastructure/http/handlers/mocks"
+	"question-answer/internal/infrastructure/http/middleware"
+	slogdiscard "question-answer/pkg/sl_logger/slog_discard"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVoteHandler(t *testing.T) {
+	voter := &auth.Principal{UserID: 9, Username: "voter"}
+
+	cases := []struct {
+		name           string
+		reqBody        string
+		callService    bool
+		vote           qa.Vote
+		mockReturnA    *qa.Answer
+		mockReturnErr  error
+		expectedStatus int
+		expectedScore  int64
+		expectedVote   string
+	}{
+		{
+			name:           "Upvote",
+			reqBody:        `{"value": "up"}`,
+			callService:    true,
+			vote:           qa.VoteUp,
+			mockReturnA:    &qa.Answer{ID: 3, Score: 4, ViewerVote: qa.VoteUp},
+			expectedStatus: http.StatusOK,
+			expectedScore:  4,
+			expectedVote:   "up",
+		},
+		{
+			name:           "Invalid value",
+			reqBody:        `{"value": "sideways"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Own answer",
+			reqBody:        `{"value": "down"}`,
+			callService:    true,
+			vote:           qa.VoteDown,
+			mockReturnErr:  qa.ErrSelfVote,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Missing answer",
+			reqBody:        `{"value": "up"}`,
+			callService:    true,
+			vote:           qa.VoteUp,
+			mockReturnErr:  fmt.Errorf("storage: %w", qa.ErrAnswerNotFound),
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svcMock := mocks.NewService(t)
+
+			if tc.callService {
+				svcMock.On("Vote", uint64(3), qa.Actor{UserID: voter.UserID}, tc.vote).
+					Return(tc.mockReturnA, tc.mockReturnErr).
+					Once()
+			}
+
+			handler := handlers.NewVoteHandler(slogdiscard.NewDiscardLogger(), svcMock, true, "3")
+
+			req := httptest.NewRequest(http.MethodPost, "/answers/3/vote", bytes.NewReader([]byte(tc.reqBody)))
+			req = req.WithContext(middleware.WithPrincipal(req.Context(), voter))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expectedStatus, rr.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var resp dto.VoteResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				require.Equal(t, tc.expectedScore, resp.Score)
+				require.Equal(t, tc.expectedVote, resp.MyVote)
+			}
+
+			svcMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestVoteQuestionHandler(t *testing.T) {
+	voter := &auth.Principal{UserID: 9, Username: "voter"}
+
+	svcMock := mocks.NewService(t)
+	svcMock.On("VoteQuestion", uint64(4), qa.Actor{UserID: voter.UserID}, qa.VoteDown).
+		Return(&qa.Question{ID: 4, Score: -1, ViewerVote: qa.VoteDown}, nil).
+		Once()
+	svcMock.On("UnvoteQuestion", uint64(4), qa.Actor{UserID: voter.UserID}).
+		Return(nil, qa.ErrQuestionNotFound).
+		Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/questions/4/vote", bytes.NewReader([]byte(`{"value": "down"}`)))
+	req = req.WithContext(middleware.WithPrincipal(req.Context(), voter))
+
+	rr := httptest.NewRecorder()
+	handlers.NewVoteHandler(slogdiscard.NewDiscardLogger(), svcMock, false, "4").ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp dto.VoteResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Equal(t, uint64(4), resp.QuestionID)
+	require.Zero(t, resp.AnswerID)
+	require.Equal(t, int64(-1), resp.Score)
+	require.Equal(t, "down", resp.MyVote)
+
+	req = httptest.NewRequest(http.MethodDelete, "/questions/4/vote", nil)
+	req = req.WithContext(middleware.WithPrincipal(req.Context(), voter))
+
+	rr = httptest.NewRecorder()
+	handlers.NewUnvoteHandler(slogdiscard.NewDiscardLogger(), svcMock, false, "4").ServeHTTP(rr, req)
+	require.Equal(t, http.StatusNotFound, rr.Code)
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestGetUserHandlerShowsReputation(t *testing.T) {
+	users := mocks.NewAuthService(t)
+	users.On("GetUser", uint64(5)).Return(&auth.User{ID: 5, Username: "helper"}, nil).Once()
+
+	svcMock := mocks.NewService(t)
+	svcMock.On("GetReputation", uint64(5)).Return(int64(42), nil).Once()
+
+	rr := httptest.NewRecorder()
+	handlers.NewGetUserHandler(slogdiscard.NewDiscardLogger(), users, svcMock, "5").
+		ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/users/5", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp dto.UserProfileResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.NotNil(t, resp.User.Reputation)
+	require.Equal(t, int64(42), *resp.User.Reputation)
+
+	svcMock.AssertExpectations(t)
+	users.AssertExpectations(t)
+}
@@ -0,0 +1,106 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	auth "question-answer/internal/domain/users"
+	"question-answer/internal/infrastructure/http/middleware"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequirePermission(t *testing.T) {
+	cases := []struct {
+		name           string
+		principal      *auth.Principal
+		expectedStatus int
+	}{
+		{
+			name:           "Anonymous",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Member",
+			principal:      &auth.Principal{UserID: 2, Roles: []auth.Role{auth.RoleMember}},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Moderator",
+			principal:      &auth.Principal{UserID: 3, Roles: []auth.Role{auth.RoleModerator, auth.RoleMember}},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Admin",
+			principal:      &auth.Principal{UserID: 4, Roles: []auth.Role{auth.RoleAdmin, auth.RoleMember}},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := middleware.RequirePermission(auth.PermManageRoles)(ok)
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/admin/users/1/roles", nil)
+			if tc.principal != nil {
+				req = req.WithContext(middleware.WithPrincipal(req.Context(), tc.principal))
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expectedStatus, rr.Code)
+		})
+	}
+}
+
+func TestRequireScope(t *testing.T) {
+	cases := []struct {
+		name           string
+		principal      *auth.Principal
+		expectedStatus int
+	}{
+		{
+			name:           "Anonymous",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Session",
+			principal:      &auth.Principal{UserID: 2, SessionID: "s1"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "TokenWithScope",
+			principal:      &auth.Principal{UserID: 2, APITokenID: 1, Scopes: []auth.Scope{auth.ScopeQuestionsWrite}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "TokenWithoutScope",
+			principal:      &auth.Principal{UserID: 2, APITokenID: 1, Scopes: []auth.Scope{auth.ScopeQuestionsRead}},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := middleware.RequireScope(auth.ScopeQuestionsWrite)(ok)
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/questions", nil)
+			if tc.principal != nil {
+				req = req.WithContext(middleware.WithPrincipal(req.Context(), tc.principal))
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expectedStatus, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package mail
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OutboxMailer writes every message as an .eml file into a directory
+// instead of delivering it. It is meant for local development and tests.
+type OutboxMailer struct {
+	dir  string
+	from string
+
+	mu  sync.Mutex
+	seq int
+}
+
+func NewOutboxMailer(dir, from string) (*OutboxMailer, error) {
+	const op = "mail.NewOutboxMailer"
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &OutboxMailer{dir: dir, from: from}, nil
+}
+
+func (m *OutboxMailer) Send(to, subject, body string) error {
+	const op = "mail.OutboxMailer.Send"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.seq++
+
+	name := fmt.Sprintf("%s-%04d-%s.eml",
+		now.UTC().Format("20060102T150405"),
+		m.seq,
+		strings.NewReplacer("@", "_at_", "/", "_", "\\", "_").Replace(to),
+	)
+
+	if err := os.WriteFile(filepath.Join(m.dir, name), compose(m.from, to, subject, body, now), 0o644); err != nil {
+		return fmt.Errorf("%s: %w: %w", op, ErrSend, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package mail
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxMailer(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "outbox")
+
+	m, err := NewOutboxMailer(dir, "noreply@example.com")
+	require.NoError(t, err)
+
+	require.NoError(t, m.Send("alice@example.com", "Сброс пароля", "line one\nline two"))
+	require.NoError(t, m.Send("bob@example.com", "Hello", "hi"))
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	require.True(t, strings.HasSuffix(files[0].Name(), "alice_at_example.com.eml"))
+
+	raw, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+	require.NoError(t, err)
+
+	msg := string(raw)
+	require.Contains(t, msg, "From: noreply@example.com\r\n")
+	require.Contains(t, msg, "To: alice@example.com\r\n")
+	require.Contains(t, msg, "Subject: =?utf-8?q?")
+	require.True(t, strings.HasSuffix(msg, "\r\n\r\nline one\r\nline two"))
+}
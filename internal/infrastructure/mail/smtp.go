@@ -0,0 +1,44 @@
+package mail
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+	"time"
+)
+
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// SMTPMailer sends messages through an SMTP relay. Authentication is used
+// only when a username is configured.
+type SMTPMailer struct {
+	cfg SMTPConfig
+}
+
+func NewSMTPMailer(cfg SMTPConfig) *SMTPMailer {
+	return &SMTPMailer{cfg: cfg}
+}
+
+func (m *SMTPMailer) Send(to, subject, body string) error {
+	const op = "mail.SMTPMailer.Send"
+
+	var auth smtp.Auth
+	if m.cfg.Username != "" {
+		auth = smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+	}
+
+	addr := net.JoinHostPort(m.cfg.Host, m.cfg.Port)
+	msg := compose(m.cfg.From, to, subject, body, time.Now())
+
+	if err := smtp.SendMail(addr, auth, m.cfg.From, []string{to}, msg); err != nil {
+		return fmt.Errorf("%s: %w: %w", op, ErrSend, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,250 @@
+// Package oidc implements the OpenID Connect authorization-code flow
+// against an external identity provider.
+package oidc
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	auth "question-answer/internal/domain/users"
+)
+
+var (
+	ErrDiscovery       = errors.New("oidc discovery failed")
+	ErrIssuerMismatch  = errors.New("issuer in discovery document does not match")
+	ErrExchange        = errors.New("failed to exchange authorization code")
+	ErrInvalidIDToken  = errors.New("invalid id token")
+	ErrNonceMismatch   = errors.New("id token nonce does not match")
+	ErrMissingIDToken  = errors.New("token response has no id token")
+	ErrProviderRefused = errors.New("identity provider returned an error")
+)
+
+// clockSkew is tolerated between us and the provider when checking token
+// timestamps.
+const clockSkew = 30 * time.Second
+
+type Config struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type Provider struct {
+	cfg    Config
+	client *http.Client
+	meta   metadata
+	keys   *keySet
+	now    func() time.Time
+}
+
+// New fetches the provider's discovery document. A nil client means
+// http.DefaultClient.
+func New(ctx context.Context, cfg Config, client *http.Client) (*Provider, error) {
+	const op = "oidc.New"
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	wellKnown := strings.TrimSuffix(cfg.IssuerURL, "/") + "/.well-known/openid-configuration"
+
+	var meta metadata
+	if err := getJSON(ctx, client, wellKnown, &meta); err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrDiscovery, err)
+	}
+	if meta.Issuer != cfg.IssuerURL {
+		return nil, fmt.Errorf("%s: %w: got %q, want %q", op, ErrIssuerMismatch, meta.Issuer, cfg.IssuerURL)
+	}
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, fmt.Errorf("%s: %w: incomplete discovery document", op, ErrDiscovery)
+	}
+
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "profile", "email"}
+	}
+
+	return &Provider{
+		cfg:    cfg,
+		client: client,
+		meta:   meta,
+		keys:   newKeySet(client, meta.JWKSURI),
+		now:    time.Now,
+	}, nil
+}
+
+// AuthCodeURL returns the provider URL the user is sent to for signing in.
+func (p *Provider) AuthCodeURL(state, nonce string) string {
+	q := url.Values{
+		"response_type": {"code"},
+		"client_id":     {p.cfg.ClientID},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"scope":         {strings.Join(p.cfg.Scopes, " ")},
+		"state":         {state},
+		"nonce":         {nonce},
+	}
+
+	sep := "?"
+	if strings.Contains(p.meta.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return p.meta.AuthorizationEndpoint + sep + q.Encode()
+}
+
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Exchange redeems an authorization code and returns the identity from the
+// verified ID token, which must carry nonce.
+func (p *Provider) Exchange(ctx context.Context, code, nonce string) (*auth.ExternalIdentity, error) {
+	const op = "oidc.Provider.Exchange"
+
+	form := url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {p.cfg.RedirectURL},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrExchange, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrExchange, err)
+	}
+	defer resp.Body.Close()
+
+	var tr tokenResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&tr); err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrExchange, err)
+	}
+	if resp.StatusCode != http.StatusOK || tr.Error != "" {
+		return nil, fmt.Errorf("%s: %w: %s %s", op, ErrProviderRefused, tr.Error, tr.ErrorDescription)
+	}
+	if tr.IDToken == "" {
+		return nil, fmt.Errorf("%s: %w", op, ErrMissingIDToken)
+	}
+
+	id, err := p.VerifyIDToken(ctx, tr.IDToken, nonce)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+type idClaims struct {
+	jwt.RegisteredClaims
+	Nonce             string   `json:"nonce"`
+	AuthorizedParty   string   `json:"azp"`
+	Email             string   `json:"email"`
+	EmailVerified     flexBool `json:"email_verified"`
+	PreferredUsername string   `json:"preferred_username"`
+	Name              string   `json:"name"`
+}
+
+// VerifyIDToken checks the signature of raw against the provider's JWKS,
+// its issuer, audience, lifetime and nonce.
+func (p *Provider) VerifyIDToken(ctx context.Context, raw, nonce string) (*auth.ExternalIdentity, error) {
+	const op = "oidc.Provider.VerifyIDToken"
+
+	var c idClaims
+	_, err := jwt.ParseWithClaims(raw, &c, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return p.keys.key(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithIssuer(p.meta.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(clockSkew),
+		jwt.WithTimeFunc(p.now),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrInvalidIDToken, err)
+	}
+
+	if len(c.Audience) > 1 && c.AuthorizedParty != p.cfg.ClientID {
+		return nil, fmt.Errorf("%s: %w: unexpected azp %q", op, ErrInvalidIDToken, c.AuthorizedParty)
+	}
+	if c.Subject == "" {
+		return nil, fmt.Errorf("%s: %w: missing sub", op, ErrInvalidIDToken)
+	}
+	if subtle.ConstantTimeCompare([]byte(c.Nonce), []byte(nonce)) != 1 {
+		return nil, fmt.Errorf("%s: %w", op, ErrNonceMismatch)
+	}
+
+	return &auth.ExternalIdentity{
+		Issuer:            c.Issuer,
+		Subject:           c.Subject,
+		Email:             c.Email,
+		EmailVerified:     bool(c.EmailVerified),
+		PreferredUsername: c.PreferredUsername,
+		Name:              c.Name,
+	}, nil
+}
+
+// flexBool accepts both JSON booleans and the "true"/"false" strings some
+// providers send for email_verified.
+type flexBool bool
+
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case bool:
+		*b = flexBool(v)
+	case string:
+		*b = flexBool(v == "true")
+	}
+	return nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, dst any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: unexpected status %s", url, resp.Status)
+	}
+
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(dst)
+}
@@ -0,0 +1,179 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+
+	"question-answer/internal/infrastructure/oidc/oidctest"
+)
+
+func newTestProvider(t *testing.T) (*Provider, *oidctest.Server) {
+	t.Helper()
+
+	idp := oidctest.NewServer("qa-client", "qa secret", oidctest.User{
+		Subject:           "user-42",
+		Email:             "Gopher@Example.com",
+		EmailVerified:     true,
+		PreferredUsername: "gopher",
+	})
+	t.Cleanup(idp.Close)
+
+	p, err := New(context.Background(), Config{
+		IssuerURL:    idp.Issuer(),
+		ClientID:     "qa-client",
+		ClientSecret: "qa secret",
+		RedirectURL:  "http://localhost:8082/auth/oidc/callback",
+	}, idp.Client())
+	require.NoError(t, err)
+
+	return p, idp
+}
+
+// authorize follows the authorization URL and returns the code and state
+// the provider redirects back with.
+func authorize(t *testing.T, p *Provider, state, nonce string) (string, string) {
+	t.Helper()
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+
+	resp, err := client.Get(p.AuthCodeURL(state, nonce))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	loc, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	require.Equal(t, "/auth/oidc/callback", loc.Path)
+
+	return loc.Query().Get("code"), loc.Query().Get("state")
+}
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	p, idp := newTestProvider(t)
+
+	code, state := authorize(t, p, "state-1", "nonce-1")
+	require.Equal(t, "state-1", state)
+
+	id, err := p.Exchange(context.Background(), code, "nonce-1")
+	require.NoError(t, err)
+	require.Equal(t, idp.Issuer(), id.Issuer)
+	require.Equal(t, "user-42", id.Subject)
+	require.Equal(t, "Gopher@Example.com", id.Email)
+	require.True(t, id.EmailVerified)
+	require.Equal(t, "gopher", id.PreferredUsername)
+
+	// Codes are single use.
+	_, err = p.Exchange(context.Background(), code, "nonce-1")
+	require.ErrorIs(t, err, ErrProviderRefused)
+}
+
+func TestExchangeNonceMismatch(t *testing.T) {
+	p, _ := newTestProvider(t)
+
+	code, _ := authorize(t, p, "state-1", "nonce-1")
+
+	_, err := p.Exchange(context.Background(), code, "other-nonce")
+	require.ErrorIs(t, err, ErrNonceMismatch)
+}
+
+func TestVerifyIDTokenRejects(t *testing.T) {
+	p, idp := newTestProvider(t)
+
+	cases := []struct {
+		name   string
+		mutate func(jwt.MapClaims)
+	}{
+		{"WrongAudience", func(c jwt.MapClaims) { c["aud"] = "someone-else" }},
+		{"WrongIssuer", func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }},
+		{"Expired", func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }},
+		{"NoSubject", func(c jwt.MapClaims) { delete(c, "sub") }},
+		{"ForeignAZP", func(c jwt.MapClaims) {
+			c["aud"] = []string{"qa-client", "other"}
+			c["azp"] = "other"
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			claims := idp.Claims("nonce")
+			tc.mutate(claims)
+
+			_, err := p.VerifyIDToken(context.Background(), idp.SignIDToken(claims), "nonce")
+			require.ErrorIs(t, err, ErrInvalidIDToken)
+		})
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	p, idp := newTestProvider(t)
+
+	now := time.Now()
+	p.keys.now = func() time.Time { return now }
+
+	_, err := p.VerifyIDToken(context.Background(), idp.SignIDToken(idp.Claims("n")), "n")
+	require.NoError(t, err)
+
+	idp.RotateKey()
+
+	// The JWKS was just fetched, so an unknown key is not looked up again.
+	_, err = p.VerifyIDToken(context.Background(), idp.SignIDToken(idp.Claims("n")), "n")
+	require.ErrorIs(t, err, ErrUnknownKey)
+
+	now = now.Add(minRefreshInterval)
+
+	_, err = p.VerifyIDToken(context.Background(), idp.SignIDToken(idp.Claims("n")), "n")
+	require.NoError(t, err)
+}
+
+func TestStateCodec(t *testing.T) {
+	c := NewStateCodec([]byte("secret"), time.Minute)
+
+	state, nonce, cookie, err := c.New()
+	require.NoError(t, err)
+
+	got, err := c.Verify(cookie, state)
+	require.NoError(t, err)
+	require.Equal(t, nonce, got)
+
+	_, err = c.Verify(cookie, "other-state")
+	require.ErrorIs(t, err, ErrInvalidState)
+
+	_, err = NewStateCodec([]byte("other"), time.Minute).Verify(cookie, state)
+	require.ErrorIs(t, err, ErrInvalidState)
+
+	c.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	_, err = c.Verify(cookie, state)
+	require.ErrorIs(t, err, ErrInvalidState)
+}
+
+func TestStateCodecChallenge(t *testing.T) {
+	c := NewStateCodec([]byte("secret"), time.Minute)
+
+	cookie, err := c.NewChallenge("https://sso.example.com", "a|b")
+	require.NoError(t, err)
+
+	issuer, subject, err := c.VerifyChallenge(cookie)
+	require.NoError(t, err)
+	require.Equal(t, "https://sso.example.com", issuer)
+	require.Equal(t, "a|b", subject)
+
+	// State cookies and challenges are signed apart.
+	state, _, stateCookie, err := c.New()
+	require.NoError(t, err)
+	_, _, err = c.VerifyChallenge(stateCookie)
+	require.ErrorIs(t, err, ErrInvalidChallenge)
+	_, err = c.Verify(cookie, state)
+	require.ErrorIs(t, err, ErrInvalidState)
+
+	c.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	_, _, err = c.VerifyChallenge(cookie)
+	require.ErrorIs(t, err, ErrInvalidChallenge)
+}
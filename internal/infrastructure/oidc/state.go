@@ -0,0 +1,136 @@
+package oidc
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidState     = errors.New("invalid or expired oidc state")
+	ErrInvalidChallenge = errors.New("invalid or expired two-factor challenge")
+)
+
+// StateCodec binds the state and nonce of a login attempt to the browser
+// that started it. Both travel in an HMAC-signed cookie, so no server-side
+// storage is needed.
+type StateCodec struct {
+	key []byte
+	ttl time.Duration
+	now func() time.Time
+}
+
+func NewStateCodec(secret []byte, ttl time.Duration) *StateCodec {
+	return &StateCodec{key: secret, ttl: ttl, now: time.Now}
+}
+
+func (c *StateCodec) TTL() time.Duration { return c.ttl }
+
+// New returns a fresh state and nonce along with the cookie value that
+// carries them.
+func (c *StateCodec) New() (state, nonce, cookie string, err error) {
+	if state, err = randomString(); err != nil {
+		return "", "", "", err
+	}
+	if nonce, err = randomString(); err != nil {
+		return "", "", "", err
+	}
+
+	exp := strconv.FormatInt(c.now().Add(c.ttl).Unix(), 10)
+	payload := base64.RawURLEncoding.EncodeToString([]byte(state + "|" + nonce + "|" + exp))
+
+	return state, nonce, payload + "." + c.sign(payload), nil
+}
+
+// Verify checks the cookie's signature and expiry and that it was issued
+// for state. It returns the nonce the ID token must carry.
+func (c *StateCodec) Verify(cookie, state string) (string, error) {
+	payload, sig, ok := strings.Cut(cookie, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(c.sign(payload))) {
+		return "", ErrInvalidState
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return "", ErrInvalidState
+	}
+
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 3 {
+		return "", ErrInvalidState
+	}
+
+	exp, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil || !c.now().Before(time.Unix(exp, 0)) {
+		return "", ErrInvalidState
+	}
+	if subtle.ConstantTimeCompare([]byte(parts[0]), []byte(state)) != 1 {
+		return "", ErrInvalidState
+	}
+
+	return parts[1], nil
+}
+
+// challengeLabel keeps challenge signatures from passing for state ones.
+const challengeLabel = "2fa."
+
+type challenge struct {
+	Issuer  string `json:"iss"`
+	Subject string `json:"sub"`
+	Exp     int64  `json:"exp"`
+}
+
+// NewChallenge returns the cookie value for a login that the provider has
+// verified but that still waits for the user's second factor. It lasts as
+// long as a state.
+func (c *StateCodec) NewChallenge(issuer, subject string) (string, error) {
+	b, err := json.Marshal(challenge{Issuer: issuer, Subject: subject, Exp: c.now().Add(c.ttl).Unix()})
+	if err != nil {
+		return "", err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(b)
+
+	return payload + "." + c.sign(challengeLabel+payload), nil
+}
+
+// VerifyChallenge checks the cookie's signature and expiry and returns the
+// identity that passed the provider.
+func (c *StateCodec) VerifyChallenge(cookie string) (issuer, subject string, err error) {
+	payload, sig, ok := strings.Cut(cookie, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(c.sign(challengeLabel+payload))) {
+		return "", "", ErrInvalidChallenge
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return "", "", ErrInvalidChallenge
+	}
+
+	var ch challenge
+	if err := json.Unmarshal(raw, &ch); err != nil || !c.now().Before(time.Unix(ch.Exp, 0)) {
+		return "", "", ErrInvalidChallenge
+	}
+
+	return ch.Issuer, ch.Subject, nil
+}
+
+func (c *StateCodec) sign(payload string) string {
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
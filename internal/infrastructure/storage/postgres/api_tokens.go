@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	auth "question-answer/internal/domain/users"
+	"question-answer/internal/infrastructure/storage/postgres/dto"
+)
+
+var (
+	ErrCreateAPIToken = errors.New("failed to create api token")
+	ErrGetAPIToken    = errors.New("failed to get api token")
+	ErrListAPITokens  = errors.New("failed to list api tokens")
+	ErrRevokeAPIToken = errors.New("failed to revoke api token")
+	ErrTouchAPIToken  = errors.New("failed to update api token usage")
+)
+
+// lastUsedResolution limits how often last_used_at is written for a busy
+// token.
+const lastUsedResolution = time.Minute
+
+func (s *PostgresStorage) CreateAPIToken(t auth.APIToken) (*auth.APIToken, error) {
+	const op = "storage.postgres.CreateAPIToken"
+
+	dto := pgdto.ToDTOAPIToken(t)
+
+	if err := s.db.Create(&dto).Error; err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, fmt.Errorf("%s: %w", op, auth.ErrUserNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrCreateAPIToken, err)
+	}
+
+	created := pgdto.ToDomainAPIToken(dto)
+	return &created, nil
+}
+
+func (s *PostgresStorage) GetAPITokenByHash(hash string) (*auth.APIToken, error) {
+	const op = "storage.postgres.GetAPITokenByHash"
+
+	var dto pgdto.APITokenDTO
+
+	if err := s.db.Where("token_hash = ?", hash).First(&dto).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, auth.ErrAPITokenNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrGetAPIToken, err)
+	}
+
+	t := pgdto.ToDomainAPIToken(dto)
+	return &t, nil
+}
+
+func (s *PostgresStorage) ListAPITokens(userID uint64) ([]auth.APIToken, error) {
+	const op = "storage.postgres.ListAPITokens"
+
+	var dtos []pgdto.APITokenDTO
+
+	if err := s.db.Where("user_id = ? AND revoked_at IS NULL", userID).
+		Order("id DESC").
+		Find(&dtos).Error; err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrListAPITokens, err)
+	}
+
+	tokens := make([]auth.APIToken, 0, len(dtos))
+	for _, dto := range dtos {
+		tokens = append(tokens, pgdto.ToDomainAPIToken(dto))
+	}
+
+	return tokens, nil
+}
+
+func (s *PostgresStorage) RevokeAPIToken(userID, id uint64, at time.Time) error {
+	const op = "storage.postgres.RevokeAPIToken"
+
+	res := s.db.Model(&pgdto.APITokenDTO{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", at)
+	if res.Error != nil {
+		return fmt.Errorf("%s: %w: %w", op, ErrRevokeAPIToken, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, auth.ErrAPITokenNotFound)
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) TouchAPIToken(id uint64, at time.Time) error {
+	const op = "storage.postgres.TouchAPIToken"
+
+	err := s.db.Model(&pgdto.APITokenDTO{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, at.Add(-lastUsedResolution)).
+		Update("last_used_at", at).Error
+	if err != nil {
+		return fmt.Errorf("%s: %w: %w", op, ErrTouchAPIToken, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	auth "question-answer/internal/domain/users"
+	"question-answer/internal/infrastructure/storage/postgres/dto"
+)
+
+var (
+	ErrRecordAuthEvent = errors.New("failed to record auth event")
+	ErrListAuthEvents  = errors.New("failed to list auth events")
+	ErrCountFailures   = errors.New("failed to count login failures")
+)
+
+func (s *PostgresStorage) RecordAuthEvent(e auth.AuthEvent) error {
+	const op = "storage.postgres.RecordAuthEvent"
+
+	dto := pgdto.ToDTOAuthEvent(e)
+
+	if err := s.db.Create(&dto).Error; err != nil {
+		return fmt.Errorf("%s: %w: %w", op, ErrRecordAuthEvent, err)
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) ListAuthEvents(f auth.AuthEventFilter) ([]auth.AuthEvent, error) {
+	const op = "storage.postgres.ListAuthEvents"
+
+	q := s.db.Model(&pgdto.AuthEventDTO{})
+	if f.UserID != 0 {
+		q = q.Where("user_id = ?", f.UserID)
+	}
+	if f.Username != "" {
+		q = q.Where("username = ?", f.Username)
+	}
+	if f.IP != "" {
+		q = q.Where("ip = ?", f.IP)
+	}
+	if f.Type != "" {
+		q = q.Where("type = ?", string(f.Type))
+	}
+	if f.Limit > 0 {
+		q = q.Limit(f.Limit)
+	}
+
+	var dtos []pgdto.AuthEventDTO
+	if err := q.Order("id DESC").Find(&dtos).Error; err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrListAuthEvents, err)
+	}
+
+	events := make([]auth.AuthEvent, 0, len(dtos))
+	for _, dto := range dtos {
+		events = append(events, pgdto.ToDomainAuthEvent(dto))
+	}
+
+	return events, nil
+}
+
+type failureRow struct {
+	Count int
+	Last  *time.Time
+}
+
+func (r failureRow) stats() auth.FailureStats {
+	stats := auth.FailureStats{Count: r.Count}
+	if r.Last != nil {
+		stats.Last = *r.Last
+	}
+	return stats
+}
+
+func (s *PostgresStorage) UserLoginFailures(username string, since time.Time) (auth.FailureStats, error) {
+	const op = "storage.postgres.UserLoginFailures"
+
+	var row failureRow
+	err := s.db.Raw(`
+		SELECT COUNT(*) AS count, MAX(created_at) AS last
+		FROM auth_events
+		WHERE type = ? AND username = ? AND created_at > GREATEST(?::timestamp, COALESCE(
+			(SELECT MAX(created_at) FROM auth_events WHERE type = ? AND username = ?),
+			?::timestamp))`,
+		string(auth.EventLoginFailed), username, since,
+		string(auth.EventLogin), username, since,
+	).Scan(&row).Error
+	if err != nil {
+		return auth.FailureStats{}, fmt.Errorf("%s: %w: %w", op, ErrCountFailures, err)
+	}
+
+	return row.stats(), nil
+}
+
+func (s *PostgresStorage) IPLoginFailures(ip string, since time.Time) (auth.FailureStats, error) {
+	const op = "storage.postgres.IPLoginFailures"
+
+	var row failureRow
+	err := s.db.Model(&pgdto.AuthEventDTO{}).
+		Select("COUNT(*) AS count, MAX(created_at) AS last").
+		Where("type = ? AND ip = ? AND created_at > ?", string(auth.EventLoginFailed), ip, since).
+		Scan(&row).Error
+	if err != nil {
+		return auth.FailureStats{}, fmt.Errorf("%s: %w: %w", op, ErrCountFailures, err)
+	}
+
+	return row.stats(), nil
+}
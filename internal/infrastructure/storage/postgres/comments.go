@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"question-answer/internal/domain/qa"
+	"question-answer/internal/infrastructure/storage/postgres/dto"
+)
+
+var (
+	ErrCreateComment = errors.New("failed to create comment")
+	ErrGetComment    = errors.New("failed to get comment")
+	ErrDeleteComment = errors.New("failed to delete comment")
+)
+
+func (s *PostgresStorage) CreateComment(c qa.Comment) (*qa.Comment, error) {
+	const op = "storage.postgres.CreateComment"
+
+	dto := pgdto.ToDTOComment(c)
+
+	if err := s.db.Create(&dto).Error; err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrCreateComment, err)
+	}
+
+	comment := pgdto.ToDomainComment(dto)
+	return &comment, nil
+}
+
+func (s *PostgresStorage) GetComment(id uint64) (*qa.Comment, error) {
+	const op = "storage.postgres.GetComment"
+
+	var dto pgdto.CommentDTO
+
+	if err := s.db.First(&dto, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, qa.ErrCommentNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrGetComment, err)
+	}
+
+	comment := pgdto.ToDomainComment(dto)
+	return &comment, nil
+}
+
+func (s *PostgresStorage) GetQuestionComments(questionID uint64) ([]qa.Comment, error) {
+	const op = "storage.postgres.GetQuestionComments"
+
+	comments, err := s.findComments(s.db.Where("question_id = ?", questionID))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return comments, nil
+}
+
+func (s *PostgresStorage) GetAnswerComments(answerID uint64) ([]qa.Comment, error) {
+	const op = "storage.postgres.GetAnswerComments"
+
+	comments, err := s.findComments(s.db.Where("answer_id = ?", answerID))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return comments, nil
+}
+
+func (s *PostgresStorage) DeleteComment(id uint64) error {
+	const op = "storage.postgres.DeleteComment"
+
+	if err := s.db.Delete(&pgdto.CommentDTO{}, id).Error; err != nil {
+		return fmt.Errorf("%s: %w: %w", op, ErrDeleteComment, err)
+	}
+
+	return nil
+}
+
+// nestComments loads the comments of the question and its answers in one
+// query and attaches them to their items.
+func (s *PostgresStorage) nestComments(q *qa.Question, answers []qa.Answer) error {
+	query := s.db.Where("question_id = ?", q.ID)
+	if len(answers) > 0 {
+		ids := make([]uint64, len(answers))
+		for i := range answers {
+			ids[i] = answers[i].ID
+		}
+		query = query.Or("answer_id IN ?", ids)
+	}
+
+	comments, err := s.findComments(query)
+	if err != nil {
+		return err
+	}
+
+	byAnswer := make(map[uint64][]qa.Comment)
+	for _, c := range comments {
+		if c.QuestionID != 0 {
+			q.Comments = append(q.Comments, c)
+			continue
+		}
+		byAnswer[c.AnswerID] = append(byAnswer[c.AnswerID], c)
+	}
+	for i := range answers {
+		answers[i].Comments = byAnswer[answers[i].ID]
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) findComments(query *gorm.DB) ([]qa.Comment, error) {
+	var dtos []pgdto.CommentDTO
+
+	if err := query.Order("id ASC").Find(&dtos).Error; err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrGetComment, err)
+	}
+
+	res := make([]qa.Comment, len(dtos))
+	for i := range dtos {
+		res[i] = pgdto.ToDomainComment(dtos[i])
+	}
+
+	return res, nil
+}
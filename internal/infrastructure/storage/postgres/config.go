@@ -4,4 +4,8 @@ package postgres
 type Config struct {
 	DSN            string
 	MigrationsPath string
+	// SearchLanguage names the Postgres text search configuration posts
+	// are indexed with, e.g. "english" or "russian". Empty leaves the
+	// current one.
+	SearchLanguage string
 }
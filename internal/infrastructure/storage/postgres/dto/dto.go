@@ -4,12 +4,21 @@ import (
 	"question-answer/internal/domain/users"
 	"question-answer/internal/domain/qa"
 	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
 )
 
 type QuestionDTO struct {
-	ID        uint64    `gorm:"primaryKey;autoIncrement"`
-	Text      string    `gorm:"type:varchar(500);not null"`
-	CreatedAt time.Time `gorm:"autoCreateTime"`
+	ID               uint64         `gorm:"primaryKey;autoIncrement"`
+	UserID           *uint64        `gorm:"index"`
+	Title            string         `gorm:"type:varchar(150);not null"`
+	Body             string         `gorm:"type:text;not null"`
+	CreatedAt        time.Time      `gorm:"autoCreateTime"`
+	AcceptedAnswerID *uint64        `gorm:"index"`
+	DuplicateOfID    *uint64        `gorm:"index"`
+	Score            int64          `gorm:"not null;default:0"`
+	DeletedAt        gorm.DeletedAt `gorm:"index"`
 }
 
 func (QuestionDTO) TableName() string {
@@ -17,11 +26,13 @@ func (QuestionDTO) TableName() string {
 }
 
 type AnswerDTO struct {
-	ID         uint64    `gorm:"primaryKey;autoIncrement"`
-	QuestionID uint64    `gorm:"index;not null"`
-	UserID     uint64    `gorm:"not null"`
-	Text       string    `gorm:"type:varchar(1000);not null"`
-	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	ID         uint64         `gorm:"primaryKey;autoIncrement"`
+	QuestionID uint64         `gorm:"index;not null"`
+	UserID     uint64         `gorm:"not null"`
+	Text       string         `gorm:"type:varchar(1000);not null"`
+	CreatedAt  time.Time      `gorm:"autoCreateTime"`
+	Score      int64          `gorm:"not null;default:0"`
+	DeletedAt  gorm.DeletedAt `gorm:"index"`
 }
 
 func (AnswerDTO) TableName() string {
@@ -29,30 +40,262 @@ func (AnswerDTO) TableName() string {
 }
 
 type UserDTO struct {
-	ID           uint64 `gorm:"primaryKey;autoIncrement"`
-	Username     string `gorm:"type:varchar(32);unique;not null"`
-	PasswordHash string `gorm:"type:varchar(255);not null"`
+	ID           uint64    `gorm:"primaryKey;autoIncrement"`
+	Username     string    `gorm:"type:varchar(32);unique;not null"`
+	Email        *string   `gorm:"type:varchar(254);unique"`
+	PasswordHash string    `gorm:"type:varchar(255);not null"`
+	TOTPSecret   *string   `gorm:"column:totp_secret;type:varchar(64)"`
+	TOTPEnabled  bool      `gorm:"column:totp_enabled;not null;default:false"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
 }
 
 func (UserDTO) TableName() string {
 	return "users"
 }
 
+type VoteDTO struct {
+	AnswerID  uint64    `gorm:"primaryKey"`
+	UserID    uint64    `gorm:"primaryKey"`
+	Value     int       `gorm:"type:smallint;not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (VoteDTO) TableName() string {
+	return "votes"
+}
+
+type QuestionVoteDTO struct {
+	QuestionID uint64    `gorm:"primaryKey"`
+	UserID     uint64    `gorm:"primaryKey"`
+	Value      int       `gorm:"type:smallint;not null"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+}
+
+func (QuestionVoteDTO) TableName() string {
+	return "question_votes"
+}
+
+type ReputationEventDTO struct {
+	ID         uint64 `gorm:"primaryKey;autoIncrement"`
+	UserID     uint64 `gorm:"index;not null"`
+	Event      string `gorm:"type:varchar(32);not null"`
+	Points     int64  `gorm:"not null"`
+	QuestionID *uint64
+	AnswerID   *uint64
+	ActorID    *uint64
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+func (ReputationEventDTO) TableName() string {
+	return "reputation_events"
+}
+
+type CommentDTO struct {
+	ID         uint64    `gorm:"primaryKey;autoIncrement"`
+	QuestionID *uint64   `gorm:"index"`
+	AnswerID   *uint64   `gorm:"index"`
+	UserID     uint64    `gorm:"not null"`
+	Text       string    `gorm:"type:varchar(300);not null"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+func (CommentDTO) TableName() string {
+	return "comments"
+}
+
+type RevisionDTO struct {
+	ID            uint64  `gorm:"primaryKey;autoIncrement"`
+	QuestionID    *uint64 `gorm:"index"`
+	AnswerID      *uint64 `gorm:"index"`
+	EditorID      *uint64
+	PreviousTitle string    `gorm:"type:varchar(150);not null"`
+	PreviousText  string    `gorm:"type:text;not null"`
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+}
+
+func (RevisionDTO) TableName() string {
+	return "revisions"
+}
+
+type TagDTO struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement"`
+	Name      string    `gorm:"type:varchar(32);unique;not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (TagDTO) TableName() string {
+	return "tags"
+}
+
+type TagSynonymDTO struct {
+	Synonym   string    `gorm:"primaryKey;type:varchar(32)"`
+	TagID     uint64    `gorm:"index;not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (TagSynonymDTO) TableName() string {
+	return "tag_synonyms"
+}
+
+type QuestionTagDTO struct {
+	QuestionID uint64 `gorm:"primaryKey"`
+	TagID      uint64 `gorm:"primaryKey"`
+}
+
+func (QuestionTagDTO) TableName() string {
+	return "question_tags"
+}
+
+type UserRoleDTO struct {
+	UserID    uint64 `gorm:"primaryKey"`
+	Role      string `gorm:"primaryKey;type:varchar(32)"`
+	GrantedBy *uint64
+	GrantedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (UserRoleDTO) TableName() string {
+	return "user_roles"
+}
+
+type SessionDTO struct {
+	ID        string    `gorm:"primaryKey;type:uuid"`
+	UserID    uint64    `gorm:"index;not null"`
+	CreatedAt time.Time `gorm:"not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	RevokedAt *time.Time
+}
+
+func (SessionDTO) TableName() string {
+	return "sessions"
+}
+
+type RefreshTokenDTO struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement"`
+	SessionID string    `gorm:"type:uuid;index;not null"`
+	TokenHash string    `gorm:"type:varchar(64);unique;not null"`
+	CreatedAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+}
+
+func (RefreshTokenDTO) TableName() string {
+	return "refresh_tokens"
+}
+
+type PasswordResetDTO struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement"`
+	UserID    uint64    `gorm:"index;not null"`
+	TokenHash string    `gorm:"type:varchar(64);unique;not null"`
+	CreatedAt time.Time `gorm:"not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+}
+
+func (PasswordResetDTO) TableName() string {
+	return "password_resets"
+}
+
+type RecoveryCodeDTO struct {
+	ID       uint64 `gorm:"primaryKey;autoIncrement"`
+	UserID   uint64 `gorm:"not null"`
+	CodeHash string `gorm:"type:varchar(64);not null"`
+	UsedAt   *time.Time
+}
+
+func (RecoveryCodeDTO) TableName() string {
+	return "recovery_codes"
+}
+
+type APITokenDTO struct {
+	ID         uint64         `gorm:"primaryKey;autoIncrement"`
+	UserID     uint64         `gorm:"index;not null"`
+	Name       string         `gorm:"type:varchar(64);not null"`
+	Prefix     string         `gorm:"type:varchar(16);not null"`
+	TokenHash  string         `gorm:"type:varchar(64);unique;not null"`
+	Scopes     pq.StringArray `gorm:"type:text[];not null"`
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	CreatedAt  time.Time `gorm:"not null"`
+	RevokedAt  *time.Time
+}
+
+func (APITokenDTO) TableName() string {
+	return "api_tokens"
+}
+
+type AuthEventDTO struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement"`
+	UserID    *uint64   `gorm:"index"`
+	Username  string    `gorm:"type:varchar(64);not null"`
+	Type      string    `gorm:"type:varchar(32);not null"`
+	IP        string    `gorm:"column:ip;type:varchar(64);not null"`
+	UserAgent string    `gorm:"type:varchar(256);not null"`
+	Detail    string    `gorm:"type:varchar(128);not null"`
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+func (AuthEventDTO) TableName() string {
+	return "auth_events"
+}
+
+type UserIdentityDTO struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement"`
+	UserID    uint64    `gorm:"index;not null"`
+	Issuer    string    `gorm:"type:varchar(255);not null"`
+	Subject   string    `gorm:"type:varchar(255);not null"`
+	Email     *string   `gorm:"type:varchar(254)"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (UserIdentityDTO) TableName() string {
+	return "user_identities"
+}
+
 //Question
 
 func ToDomainQuestion(q QuestionDTO) qa.Question {
+	var userID uint64
+	if q.UserID != nil {
+		userID = *q.UserID
+	}
+	var acceptedID uint64
+	if q.AcceptedAnswerID != nil {
+		acceptedID = *q.AcceptedAnswerID
+	}
+	var duplicateOfID uint64
+	if q.DuplicateOfID != nil {
+		duplicateOfID = *q.DuplicateOfID
+	}
 	return qa.Question{
-		ID:        q.ID,
-		Text:      q.Text,
-		CreatedAt: q.CreatedAt,
+		ID:               q.ID,
+		UserID:           userID,
+		Title:            q.Title,
+		Body:             q.Body,
+		CreatedAt:        q.CreatedAt,
+		AcceptedAnswerID: acceptedID,
+		DuplicateOfID:    duplicateOfID,
+		Score:            q.Score,
+		DeletedAt:        deletedAt(q.DeletedAt),
 	}
 }
 
 func ToDTOQuestion(q qa.Question) QuestionDTO {
+	var userID *uint64
+	if q.UserID != 0 {
+		userID = &q.UserID
+	}
+	var acceptedID *uint64
+	if q.AcceptedAnswerID != 0 {
+		acceptedID = &q.AcceptedAnswerID
+	}
 	return QuestionDTO{
-		ID:        q.ID,
-		Text:      q.Text,
-		CreatedAt: q.CreatedAt,
+		ID:               q.ID,
+		UserID:           userID,
+		Title:            q.Title,
+		Body:             q.Body,
+		CreatedAt:        q.CreatedAt,
+		AcceptedAnswerID: acceptedID,
 	}
 }
 
@@ -65,6 +308,8 @@ func ToDomainAnswer(a AnswerDTO) qa.Answer {
 		UserID:     a.UserID,
 		Text:       a.Text,
 		CreatedAt:  a.CreatedAt,
+		Score:      a.Score,
+		DeletedAt:  deletedAt(a.DeletedAt),
 	}
 }
 
@@ -81,17 +326,266 @@ func ToDTOAnswer(a qa.Answer) AnswerDTO {
 // User
 
 func ToDomainUser(u UserDTO) auth.User {
+	var email, totpSecret string
+	if u.Email != nil {
+		email = *u.Email
+	}
+	if u.TOTPSecret != nil {
+		totpSecret = *u.TOTPSecret
+	}
 	return auth.User{
 		ID:           u.ID,
 		Username:     u.Username,
+		Email:        email,
 		PasswordHash: u.PasswordHash,
+		TOTPSecret:   totpSecret,
+		TOTPEnabled:  u.TOTPEnabled,
+		CreatedAt:    u.CreatedAt,
 	}
 }
 
 func ToDTOUser(u auth.User) UserDTO {
+	var email, totpSecret *string
+	if u.Email != "" {
+		email = &u.Email
+	}
+	if u.TOTPSecret != "" {
+		totpSecret = &u.TOTPSecret
+	}
 	return UserDTO{
 		ID:           u.ID,
 		Username:     u.Username,
+		Email:        email,
 		PasswordHash: u.PasswordHash,
+		TOTPSecret:   totpSecret,
+		TOTPEnabled:  u.TOTPEnabled,
+		CreatedAt:    u.CreatedAt,
+	}
+}
+
+// Session
+
+func ToDomainSession(s SessionDTO) auth.Session {
+	return auth.Session{
+		ID:        s.ID,
+		UserID:    s.UserID,
+		CreatedAt: s.CreatedAt,
+		ExpiresAt: s.ExpiresAt,
+		RevokedAt: s.RevokedAt,
+	}
+}
+
+func ToDTOSession(s auth.Session) SessionDTO {
+	return SessionDTO{
+		ID:        s.ID,
+		UserID:    s.UserID,
+		CreatedAt: s.CreatedAt,
+		ExpiresAt: s.ExpiresAt,
+		RevokedAt: s.RevokedAt,
+	}
+}
+
+func ToDomainRefreshToken(t RefreshTokenDTO) auth.RefreshToken {
+	return auth.RefreshToken{
+		SessionID: t.SessionID,
+		TokenHash: t.TokenHash,
+		CreatedAt: t.CreatedAt,
+		UsedAt:    t.UsedAt,
+	}
+}
+
+func ToDTORefreshToken(t auth.RefreshToken) RefreshTokenDTO {
+	return RefreshTokenDTO{
+		SessionID: t.SessionID,
+		TokenHash: t.TokenHash,
+		CreatedAt: t.CreatedAt,
+		UsedAt:    t.UsedAt,
+	}
+}
+
+// Password reset
+
+func ToDomainPasswordReset(r PasswordResetDTO) auth.PasswordReset {
+	return auth.PasswordReset{
+		UserID:    r.UserID,
+		TokenHash: r.TokenHash,
+		CreatedAt: r.CreatedAt,
+		ExpiresAt: r.ExpiresAt,
+		UsedAt:    r.UsedAt,
+	}
+}
+
+func ToDTOPasswordReset(r auth.PasswordReset) PasswordResetDTO {
+	return PasswordResetDTO{
+		UserID:    r.UserID,
+		TokenHash: r.TokenHash,
+		CreatedAt: r.CreatedAt,
+		ExpiresAt: r.ExpiresAt,
+		UsedAt:    r.UsedAt,
+	}
+}
+
+// API token
+
+func ToDomainAPIToken(t APITokenDTO) auth.APIToken {
+	scopes := make([]auth.Scope, 0, len(t.Scopes))
+	for _, s := range t.Scopes {
+		scopes = append(scopes, auth.Scope(s))
+	}
+	return auth.APIToken{
+		ID:         t.ID,
+		UserID:     t.UserID,
+		Name:       t.Name,
+		Prefix:     t.Prefix,
+		TokenHash:  t.TokenHash,
+		Scopes:     scopes,
+		ExpiresAt:  t.ExpiresAt,
+		LastUsedAt: t.LastUsedAt,
+		CreatedAt:  t.CreatedAt,
+		RevokedAt:  t.RevokedAt,
+	}
+}
+
+func ToDTOAPIToken(t auth.APIToken) APITokenDTO {
+	scopes := make(pq.StringArray, 0, len(t.Scopes))
+	for _, s := range t.Scopes {
+		scopes = append(scopes, string(s))
+	}
+	return APITokenDTO{
+		ID:         t.ID,
+		UserID:     t.UserID,
+		Name:       t.Name,
+		Prefix:     t.Prefix,
+		TokenHash:  t.TokenHash,
+		Scopes:     scopes,
+		ExpiresAt:  t.ExpiresAt,
+		LastUsedAt: t.LastUsedAt,
+		CreatedAt:  t.CreatedAt,
+		RevokedAt:  t.RevokedAt,
+	}
+}
+
+// Auth event
+
+func ToDomainAuthEvent(e AuthEventDTO) auth.AuthEvent {
+	var userID uint64
+	if e.UserID != nil {
+		userID = *e.UserID
+	}
+	return auth.AuthEvent{
+		ID:        e.ID,
+		UserID:    userID,
+		Username:  e.Username,
+		Type:      auth.EventType(e.Type),
+		IP:        e.IP,
+		UserAgent: e.UserAgent,
+		Detail:    e.Detail,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+func ToDTOAuthEvent(e auth.AuthEvent) AuthEventDTO {
+	var userID *uint64
+	if e.UserID != 0 {
+		userID = &e.UserID
+	}
+	return AuthEventDTO{
+		ID:        e.ID,
+		UserID:    userID,
+		Username:  e.Username,
+		Type:      string(e.Type),
+		IP:        e.IP,
+		UserAgent: e.UserAgent,
+		Detail:    e.Detail,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+// Comment
+
+func ToDomainComment(c CommentDTO) qa.Comment {
+	var questionID, answerID uint64
+	if c.QuestionID != nil {
+		questionID = *c.QuestionID
+	}
+	if c.AnswerID != nil {
+		answerID = *c.AnswerID
+	}
+	return qa.Comment{
+		ID:         c.ID,
+		QuestionID: questionID,
+		AnswerID:   answerID,
+		UserID:     c.UserID,
+		Text:       c.Text,
+		CreatedAt:  c.CreatedAt,
+	}
+}
+
+func ToDTOComment(c qa.Comment) CommentDTO {
+	var questionID, answerID *uint64
+	if c.QuestionID != 0 {
+		questionID = &c.QuestionID
+	}
+	if c.AnswerID != 0 {
+		answerID = &c.AnswerID
+	}
+	return CommentDTO{
+		ID:         c.ID,
+		QuestionID: questionID,
+		AnswerID:   answerID,
+		UserID:     c.UserID,
+		Text:       c.Text,
+		CreatedAt:  c.CreatedAt,
+	}
+}
+
+// Revision
+
+func ToDomainRevision(r RevisionDTO) qa.Revision {
+	rev := qa.Revision{
+		ID:            r.ID,
+		PreviousTitle: r.PreviousTitle,
+		PreviousText:  r.PreviousText,
+		CreatedAt:     r.CreatedAt,
+	}
+	if r.QuestionID != nil {
+		rev.QuestionID = *r.QuestionID
+	}
+	if r.AnswerID != nil {
+		rev.AnswerID = *r.AnswerID
+	}
+	if r.EditorID != nil {
+		rev.EditorID = *r.EditorID
+	}
+	return rev
+}
+
+// Reputation
+
+func ToDTOReputationEvent(e qa.ReputationEntry) ReputationEventDTO {
+	dto := ReputationEventDTO{
+		ID:        e.ID,
+		UserID:    e.UserID,
+		Event:     string(e.Event),
+		Points:    e.Points,
+		CreatedAt: e.CreatedAt,
+	}
+	if e.QuestionID != 0 {
+		dto.QuestionID = &e.QuestionID
+	}
+	if e.AnswerID != 0 {
+		dto.AnswerID = &e.AnswerID
+	}
+	if e.ActorID != 0 {
+		dto.ActorID = &e.ActorID
+	}
+	return dto
+}
+
+func deletedAt(d gorm.DeletedAt) *time.Time {
+	if !d.Valid {
+		return nil
 	}
+	t := d.Time
+	return &t
 }
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"question-answer/internal/domain/qa"
+	"question-answer/internal/infrastructure/storage/postgres/dto"
+)
+
+// The list statistics are computed per row rather than stored, so ordering
+// by them scans every question the filter matches.
+const (
+	answerCountSQL = `(SELECT COUNT(*) FROM answers a
+		WHERE a.question_id = questions.id AND a.deleted_at IS NULL)`
+	lastActivitySQL = `GREATEST(questions.created_at,
+		(SELECT MAX(a.created_at) FROM answers a
+			WHERE a.question_id = questions.id AND a.deleted_at IS NULL),
+		(SELECT MAX(r.created_at) FROM revisions r
+			WHERE r.question_id = questions.id
+			   OR r.answer_id IN (SELECT a.id FROM answers a
+				WHERE a.question_id = questions.id AND a.deleted_at IS NULL)))`
+)
+
+// questionSortKeys is the allow-list of orders of the question list. Only
+// these expressions are ever put into ORDER BY.
+var questionSortKeys = map[qa.QuestionSort]string{
+	qa.SortCreated:  "questions.created_at",
+	qa.SortAnswers:  answerCountSQL,
+	qa.SortActivity: lastActivitySQL,
+}
+
+// questionRow is a listed question with its statistics.
+type questionRow struct {
+	pgdto.QuestionDTO
+	AnswerCount    int64
+	LastActivityAt time.Time
+}
+
+// likeEscaper escapes the LIKE wildcards so text matches literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func whereQuestionFilter(query *gorm.DB, f qa.QuestionFilter) *gorm.DB {
+	if f.Answered != nil {
+		// An accepted answer in the trash does not count.
+		const live = "SELECT id FROM answers WHERE deleted_at IS NULL"
+		if *f.Answered {
+			query = query.Where("questions.accepted_answer_id IN (" + live + ")")
+		} else {
+			query = query.Where("(questions.accepted_answer_id IS NULL OR questions.accepted_answer_id NOT IN (" + live + "))")
+		}
+	}
+	if f.HasAnswers != nil {
+		const exists = "EXISTS (SELECT 1 FROM answers a WHERE a.question_id = questions.id AND a.deleted_at IS NULL)"
+		if *f.HasAnswers {
+			query = query.Where(exists)
+		} else {
+			query = query.Where("NOT " + exists)
+		}
+	}
+	if f.AuthorID != 0 {
+		query = query.Where("questions.user_id = ?", f.AuthorID)
+	}
+	if f.CreatedFrom != nil {
+		query = query.Where("questions.created_at >= ?", *f.CreatedFrom)
+	}
+	if f.CreatedTo != nil {
+		query = query.Where("questions.created_at < ?", *f.CreatedTo)
+	}
+	if f.Text != "" {
+		pattern := "%" + likeEscaper.Replace(f.Text) + "%"
+		query = query.Where("(questions.title ILIKE ? OR questions.body ILIKE ?)", pattern, pattern)
+	}
+
+	return whereTags(query, f.Tags)
+}
@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	auth "question-answer/internal/domain/users"
+	"question-answer/internal/infrastructure/storage/postgres/dto"
+)
+
+var (
+	ErrGetIdentity  = errors.New("failed to get external identity")
+	ErrLinkIdentity = errors.New("failed to link external identity")
+)
+
+func (s *PostgresStorage) GetUserByIdentity(issuer, subject string) (*auth.User, error) {
+	const op = "storage.postgres.GetUserByIdentity"
+
+	var dto pgdto.UserDTO
+
+	err := s.db.Joins("JOIN user_identities ON user_identities.user_id = users.id").
+		Where("user_identities.issuer = ? AND user_identities.subject = ?", issuer, subject).
+		First(&dto).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, auth.ErrIdentityNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrGetIdentity, err)
+	}
+
+	u := pgdto.ToDomainUser(dto)
+	return &u, nil
+}
+
+func (s *PostgresStorage) LinkIdentity(userID uint64, issuer, subject, email string) error {
+	const op = "storage.postgres.LinkIdentity"
+
+	if err := linkIdentity(s.db, userID, issuer, subject, email); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) CreateExternalUser(u auth.User, issuer, subject, email string) (*auth.User, error) {
+	const op = "storage.postgres.CreateExternalUser"
+
+	dto := pgdto.ToDTOUser(u)
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&dto).Error; err != nil {
+			if isUniqueViolation(err, "users_email_key") {
+				return auth.ErrEmailExists
+			}
+			if isUniqueViolation(err, "users_username_key") {
+				return auth.ErrUserExists
+			}
+			return fmt.Errorf("%w: %w", ErrCreateUser, err)
+		}
+		return linkIdentity(tx, dto.ID, issuer, subject, email)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	domObj := pgdto.ToDomainUser(dto)
+	return &domObj, nil
+}
+
+func linkIdentity(db *gorm.DB, userID uint64, issuer, subject, email string) error {
+	dto := pgdto.UserIdentityDTO{
+		UserID:  userID,
+		Issuer:  issuer,
+		Subject: subject,
+	}
+	if email != "" {
+		dto.Email = &email
+	}
+
+	if err := db.Create(&dto).Error; err != nil {
+		if isUniqueViolation(err, "user_identities_issuer_subject_key") {
+			return auth.ErrIdentityLinked
+		}
+		if isForeignKeyViolation(err) {
+			return auth.ErrUserNotFound
+		}
+		return fmt.Errorf("%w: %w", ErrLinkIdentity, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users RENAME COLUMN name TO username;
+ALTER TABLE users ALTER COLUMN username TYPE VARCHAR(32);
+ALTER TABLE users ADD CONSTRAINT users_username_key UNIQUE (username);
+ALTER TABLE users ALTER COLUMN password_hash TYPE VARCHAR(255);
+ALTER TABLE users ADD COLUMN created_at TIMESTAMP NOT NULL DEFAULT NOW();
+-- the seeded System user was inserted with an explicit id
+SELECT setval(pg_get_serial_sequence('users', 'id'), (SELECT COALESCE(MAX(id), 1) FROM users));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN created_at;
+ALTER TABLE users ALTER COLUMN password_hash TYPE TEXT;
+ALTER TABLE users DROP CONSTRAINT users_username_key;
+ALTER TABLE users ALTER COLUMN username TYPE TEXT;
+ALTER TABLE users RENAME COLUMN username TO name;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE questions ADD COLUMN user_id BIGINT REFERENCES users(id) ON DELETE SET NULL;
+CREATE INDEX idx_questions_user_id ON questions(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_questions_user_id;
+ALTER TABLE questions DROP COLUMN user_id;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE auth_events (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    username VARCHAR(64) NOT NULL DEFAULT '',
+    type VARCHAR(32) NOT NULL,
+    ip VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent VARCHAR(256) NOT NULL DEFAULT '',
+    detail VARCHAR(128) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX auth_events_username_idx ON auth_events(username, type, created_at);
+CREATE INDEX auth_events_ip_idx ON auth_events(ip, type, created_at);
+CREATE INDEX auth_events_user_id_idx ON auth_events(user_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE auth_events;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE questions
+    ADD COLUMN accepted_answer_id BIGINT REFERENCES answers(id) ON DELETE SET NULL;
+
+CREATE INDEX questions_accepted_answer_id_idx ON questions(accepted_answer_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE questions DROP COLUMN accepted_answer_id;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE comments (
+    id BIGSERIAL PRIMARY KEY,
+    question_id BIGINT REFERENCES questions(id) ON DELETE CASCADE,
+    answer_id BIGINT REFERENCES answers(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    text VARCHAR(300) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK ((question_id IS NULL) <> (answer_id IS NULL))
+);
+
+CREATE INDEX comments_question_id_idx ON comments(question_id);
+CREATE INDEX comments_answer_id_idx ON comments(answer_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE comments;
+-- +goose StatementEnd
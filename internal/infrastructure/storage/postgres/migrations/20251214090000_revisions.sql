@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE revisions (
+    id BIGSERIAL PRIMARY KEY,
+    question_id BIGINT REFERENCES questions(id) ON DELETE CASCADE,
+    answer_id BIGINT REFERENCES answers(id) ON DELETE CASCADE,
+    editor_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    previous_text TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK ((question_id IS NULL) <> (answer_id IS NULL))
+);
+
+CREATE INDEX revisions_question_id_idx ON revisions(question_id);
+CREATE INDEX revisions_answer_id_idx ON revisions(answer_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE revisions;
+-- +goose StatementEnd
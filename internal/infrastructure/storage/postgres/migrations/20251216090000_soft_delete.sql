@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE questions ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE answers ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX questions_deleted_at_idx ON questions(deleted_at);
+CREATE INDEX answers_deleted_at_idx ON answers(deleted_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM answers WHERE deleted_at IS NOT NULL;
+DELETE FROM questions WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE answers DROP COLUMN deleted_at;
+ALTER TABLE questions DROP COLUMN deleted_at;
+-- +goose StatementEnd
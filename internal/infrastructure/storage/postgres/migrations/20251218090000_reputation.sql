@@ -0,0 +1,57 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE questions ADD COLUMN score INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE question_votes (
+    question_id BIGINT NOT NULL REFERENCES questions(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    value SMALLINT NOT NULL CHECK (value IN (-1, 1)),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (question_id, user_id)
+);
+
+CREATE INDEX question_votes_user_id_idx ON question_votes(user_id);
+
+-- The ledger outlives purged posts, so the post columns are not foreign
+-- keys.
+CREATE TABLE reputation_events (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    event VARCHAR(32) NOT NULL,
+    points INTEGER NOT NULL,
+    question_id BIGINT,
+    answer_id BIGINT,
+    actor_id BIGINT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX reputation_events_user_id_idx ON reputation_events(user_id);
+
+ALTER TABLE users ADD COLUMN reputation BIGINT NOT NULL DEFAULT 0;
+
+-- Credit votes and accepted answers cast before the ledger existed.
+INSERT INTO reputation_events (user_id, event, points, question_id, answer_id, actor_id, created_at)
+SELECT a.user_id, 'answer_upvoted', 10, a.question_id, a.id, v.user_id, v.updated_at
+FROM votes v
+JOIN answers a ON a.id = v.answer_id
+WHERE v.value = 1;
+
+INSERT INTO reputation_events (user_id, event, points, question_id, answer_id, actor_id)
+SELECT a.user_id, 'answer_accepted', 15, q.id, a.id, q.user_id
+FROM questions q
+JOIN answers a ON a.id = q.accepted_answer_id
+WHERE q.user_id IS DISTINCT FROM a.user_id;
+
+UPDATE users SET reputation = r.total
+FROM (SELECT user_id, SUM(points) AS total FROM reputation_events GROUP BY user_id) r
+WHERE users.id = r.user_id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN reputation;
+DROP TABLE reputation_events;
+DROP TABLE question_votes;
+ALTER TABLE questions DROP COLUMN score;
+-- +goose StatementEnd
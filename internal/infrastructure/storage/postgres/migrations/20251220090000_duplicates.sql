@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE questions ADD COLUMN duplicate_of_id BIGINT REFERENCES questions(id) ON DELETE SET NULL;
+
+CREATE INDEX questions_duplicate_of_id_idx ON questions(duplicate_of_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE questions DROP COLUMN duplicate_of_id;
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE questions ADD COLUMN title VARCHAR(150) NOT NULL DEFAULT '';
+ALTER TABLE questions ADD COLUMN body TEXT NOT NULL DEFAULT '';
+
+-- The first line becomes the title, shortened to fit. The body keeps the
+-- whole text unless the title already says it all.
+UPDATE questions SET title = CASE
+    WHEN char_length(split_part(text, E'\n', 1)) <= 150 THEN split_part(text, E'\n', 1)
+    ELSE left(split_part(text, E'\n', 1), 147) || '...'
+END;
+UPDATE questions SET body = text WHERE text <> title;
+
+ALTER TABLE questions ALTER COLUMN title DROP DEFAULT;
+ALTER TABLE questions DROP COLUMN text;
+
+-- Question revisions keep the previous title next to the previous body.
+ALTER TABLE revisions ADD COLUMN previous_title VARCHAR(150) NOT NULL DEFAULT '';
+
+UPDATE revisions SET previous_title = CASE
+    WHEN char_length(split_part(previous_text, E'\n', 1)) <= 150 THEN split_part(previous_text, E'\n', 1)
+    ELSE left(split_part(previous_text, E'\n', 1), 147) || '...'
+END
+WHERE question_id IS NOT NULL;
+UPDATE revisions SET previous_text = ''
+WHERE question_id IS NOT NULL AND previous_text = previous_title;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE revisions DROP COLUMN previous_title;
+
+ALTER TABLE questions ADD COLUMN text TEXT NOT NULL DEFAULT '';
+UPDATE questions SET text = left(CASE WHEN body = '' THEN title ELSE body END, 500);
+ALTER TABLE questions ALTER COLUMN text DROP DEFAULT;
+ALTER TABLE questions DROP COLUMN body;
+ALTER TABLE questions DROP COLUMN title;
+-- +goose StatementEnd
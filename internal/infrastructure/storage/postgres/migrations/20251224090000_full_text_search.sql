@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Each row remembers the text search configuration it was indexed with, so
+-- that switching qa.search.language reindexes rows by updating this column
+-- and queries always parse terms the way the row was indexed.
+ALTER TABLE questions ADD COLUMN search_config REGCONFIG NOT NULL DEFAULT 'english';
+ALTER TABLE questions ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector(search_config, title), 'A') ||
+    setweight(to_tsvector(search_config, body), 'B')
+) STORED;
+
+CREATE INDEX questions_search_vector_idx ON questions USING GIN (search_vector);
+
+ALTER TABLE answers ADD COLUMN search_config REGCONFIG NOT NULL DEFAULT 'english';
+ALTER TABLE answers ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    to_tsvector(search_config, text)
+) STORED;
+
+CREATE INDEX answers_search_vector_idx ON answers USING GIN (search_vector);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX answers_search_vector_idx;
+ALTER TABLE answers DROP COLUMN search_vector;
+ALTER TABLE answers DROP COLUMN search_config;
+
+DROP INDEX questions_search_vector_idx;
+ALTER TABLE questions DROP COLUMN search_vector;
+ALTER TABLE questions DROP COLUMN search_config;
+-- +goose StatementEnd
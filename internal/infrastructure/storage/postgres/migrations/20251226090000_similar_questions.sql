@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX questions_title_trgm_idx ON questions USING GIN (title gin_trgm_ops);
+CREATE INDEX questions_body_trgm_idx ON questions USING GIN (body gin_trgm_ops);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX questions_body_trgm_idx;
+DROP INDEX questions_title_trgm_idx;
+-- The extension may be used by others, so it stays.
+-- +goose StatementEnd
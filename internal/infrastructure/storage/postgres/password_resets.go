@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	auth "question-answer/internal/domain/users"
+	"question-answer/internal/infrastructure/storage/postgres/dto"
+)
+
+var (
+	ErrCreatePasswordReset = errors.New("failed to create password reset")
+	ErrGetPasswordReset    = errors.New("failed to get password reset")
+	ErrUsePasswordReset    = errors.New("failed to mark password reset used")
+)
+
+func (s *PostgresStorage) CreatePasswordReset(r auth.PasswordReset) error {
+	const op = "storage.postgres.CreatePasswordReset"
+
+	dto := pgdto.ToDTOPasswordReset(r)
+
+	if err := s.db.Create(&dto).Error; err != nil {
+		return fmt.Errorf("%s: %w: %w", op, ErrCreatePasswordReset, err)
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) GetPasswordReset(hash string) (*auth.PasswordReset, error) {
+	const op = "storage.postgres.GetPasswordReset"
+
+	var dto pgdto.PasswordResetDTO
+
+	if err := s.db.Where("token_hash = ?", hash).First(&dto).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, auth.ErrInvalidResetToken)
+		}
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrGetPasswordReset, err)
+	}
+
+	r := pgdto.ToDomainPasswordReset(dto)
+	return &r, nil
+}
+
+func (s *PostgresStorage) MarkPasswordResetUsed(hash string, at time.Time) (bool, error) {
+	const op = "storage.postgres.MarkPasswordResetUsed"
+
+	res := s.db.Model(&pgdto.PasswordResetDTO{}).
+		Where("token_hash = ? AND used_at IS NULL", hash).
+		Update("used_at", at)
+	if res.Error != nil {
+		return false, fmt.Errorf("%s: %w: %w", op, ErrUsePasswordReset, res.Error)
+	}
+
+	return res.RowsAffected == 1, nil
+}
@@ -5,11 +5,13 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	_ "github.com/lib/pq"
 	"github.com/pressly/goose/v3"
 	gormpg "gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"question-answer/internal/domain/qa"
 	"question-answer/internal/infrastructure/storage/postgres/dto"
@@ -26,10 +28,16 @@ var (
 	ErrCreateAnswer    = errors.New("failed to create answer")
 	ErrGetAnswer       = errors.New("failed to get answer")
 	ErrDeleteAnswer    = errors.New("failed to delete answer")
+	ErrGetUserActivity = errors.New("failed to get user activity")
+	ErrAcceptAnswer    = errors.New("failed to accept answer")
+	ErrSetDuplicate    = errors.New("failed to close question as duplicate")
 )
 
 type PostgresStorage struct {
 	db *gorm.DB
+	// searchConfig is the text search configuration posts are indexed
+	// with, which search queries are parsed with too.
+	searchConfig string
 }
 
 func New(cfg Config) (*PostgresStorage, error) {
@@ -51,21 +59,55 @@ func New(cfg Config) (*PostgresStorage, error) {
 		return nil, fmt.Errorf("%s: %w: %w", op, ErrGormOpen, err)
 	}
 
-	return &PostgresStorage{db: gormDB}, nil
+	searchConfig, err := setSearchLanguage(gormDB, cfg.SearchLanguage)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &PostgresStorage{db: gormDB, searchConfig: searchConfig}, nil
 }
 
-func (s *PostgresStorage) GetAllQuestions() ([]qa.Question, error) {
+func (s *PostgresStorage) GetAllQuestions(f qa.QuestionFilter, after qa.Cursor, limit int) ([]qa.Question, error) {
 	const op = "storage.postgres.GetAllQuestions"
 
-	var dtos []pgdto.QuestionDTO
+	key, ok := questionSortKeys[f.Sort]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w: unknown sort %q", op, qa.ErrInvalidFilter, f.Sort)
+	}
+	dir, cmp := "ASC", ">"
+	if f.Desc {
+		dir, cmp = "DESC", "<"
+	}
 
-	if err := s.db.Order("id ASC").Find(&dtos).Error; err != nil {
+	var rows []questionRow
+
+	query := s.db.Model(&pgdto.QuestionDTO{}).
+		Select("questions.*, " + answerCountSQL + " AS answer_count, " + lastActivitySQL + " AS last_activity_at").
+		Order(key + " " + dir).
+		Order("questions.id " + dir).
+		Limit(limit)
+	if after.ID != 0 {
+		var afterKey any = after.Count
+		if after.At != nil {
+			afterKey = *after.At
+		}
+		query = query.Where("("+key+", questions.id) "+cmp+" (?, ?)", afterKey, after.ID)
+	}
+	query = whereQuestionFilter(query, f)
+
+	if err := query.Find(&rows).Error; err != nil {
 		return nil, fmt.Errorf("%s: %w: %w", op, ErrGetAllQuestions, err)
 	}
 
-	res := make([]qa.Question, len(dtos))
-	for i := range dtos {
-		res[i] = pgdto.ToDomainQuestion(dtos[i])
+	res := make([]qa.Question, len(rows))
+	for i, row := range rows {
+		res[i] = pgdto.ToDomainQuestion(row.QuestionDTO)
+		res[i].AnswerCount = row.AnswerCount
+		res[i].LastActivityAt = row.LastActivityAt
+	}
+
+	if err := loadTags(s.db, res); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return res, nil
@@ -76,24 +118,61 @@ func (s *PostgresStorage) CreateQuestion(q qa.Question) (*qa.Question, error) {
 
 	dto := pgdto.ToDTOQuestion(q)
 
-	if err := s.db.Create(&dto).Error; err != nil {
-		return nil, fmt.Errorf("%s: %w: %w", op, ErrCreateQuestion, err)
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&dto).Error; err != nil {
+			return fmt.Errorf("%w: %w", ErrCreateQuestion, err)
+		}
+		return attachTags(tx, dto.ID, q.Tags)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	domObj := []qa.Question{pgdto.ToDomainQuestion(dto)}
+	if err := loadTags(s.db, domObj); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &domObj[0], nil
+}
+
+func (s *PostgresStorage) GetQuestion(id uint64) (*qa.Question, error) {
+	const op = "storage.postgres.GetQuestion"
+
+	var dto pgdto.QuestionDTO
+
+	if err := s.db.First(&dto, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, qa.ErrQuestionNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrGetQuestion, err)
 	}
 
-	domObj := pgdto.ToDomainQuestion(dto)
-	return &domObj, nil
+	q := []qa.Question{pgdto.ToDomainQuestion(dto)}
+	if err := loadTags(s.db, q); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &q[0], nil
 }
 
-func (s *PostgresStorage) GetQuestionWithAnswers(id uint64) (*qa.Question, []qa.Answer, error) {
+func (s *PostgresStorage) GetQuestionWithAnswers(id, viewerID uint64) (*qa.Question, []qa.Answer, error) {
 	const op = "storage.postgres.GetQuestionWithAnswers"
 
 	var qdto pgdto.QuestionDTO
 
 	if err := s.db.First(&qdto, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, fmt.Errorf("%s: %w", op, qa.ErrQuestionNotFound)
+		}
 		return nil, nil, fmt.Errorf("%s: %w: %w", op, ErrGetQuestion, err)
 	}
 
-	question := pgdto.ToDomainQuestion(qdto)
+	questions := []qa.Question{pgdto.ToDomainQuestion(qdto)}
+	if err := loadTags(s.db, questions); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	question := questions[0]
 
 	var adtos []pgdto.AnswerDTO
 	if err := s.db.Where("question_id = ?", id).
@@ -108,20 +187,142 @@ func (s *PostgresStorage) GetQuestionWithAnswers(id uint64) (*qa.Question, []qa.
 		answers[i] = pgdto.ToDomainAnswer(adtos[i])
 	}
 
+	if viewerID != 0 {
+		vote, err := currentVote(s.db, questionVotes, id, viewerID)
+		if err != nil {
+			return &question, nil, fmt.Errorf("%s: %w: %w", op, ErrGetQuestion, err)
+		}
+		question.ViewerVote = vote
+	}
+
+	if viewerID != 0 && len(answers) > 0 {
+		var votes []pgdto.VoteDTO
+		if err := s.db.Joins("JOIN answers ON answers.id = votes.answer_id").
+			Where("answers.question_id = ? AND votes.user_id = ?", id, viewerID).
+			Find(&votes).Error; err != nil {
+
+			return &question, nil, fmt.Errorf("%s: %w: %w", op, ErrGetQuestion, err)
+		}
+
+		mine := make(map[uint64]qa.Vote, len(votes))
+		for _, v := range votes {
+			mine[v.AnswerID] = qa.Vote(v.Value)
+		}
+		for i := range answers {
+			answers[i].ViewerVote = mine[answers[i].ID]
+		}
+	}
+
+	if err := loadAuthorReputation(s.db, answers); err != nil {
+		return &question, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.nestComments(&question, answers); err != nil {
+		return &question, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	return &question, answers, nil
 }
 
+// DeleteQuestion moves the question and its answers to the trash. Both get
+// the same deleted_at so RestoreQuestion can tell the answers deleted along
+// with the question from those deleted earlier on their own.
 func (s *PostgresStorage) DeleteQuestion(id uint64) error {
 	const op = "storage.postgres.DeleteQuestion"
 
-	if err := s.db.Where("question_id = ?", id).
-		Delete(&pgdto.AnswerDTO{}).Error; err != nil {
+	now := time.Now()
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&pgdto.AnswerDTO{}).
+			Where("question_id = ?", id).
+			Update("deleted_at", now).Error; err != nil {
 
+			return err
+		}
+
+		return tx.Model(&pgdto.QuestionDTO{}).
+			Where("id = ?", id).
+			Update("deleted_at", now).Error
+	})
+	if err != nil {
 		return fmt.Errorf("%s: %w: %w", op, ErrDeleteQuestion, err)
 	}
 
-	if err := s.db.Delete(&pgdto.QuestionDTO{}, id).Error; err != nil {
-		return fmt.Errorf("%s: %w: %w", op, ErrDeleteQuestion, err)
+	return nil
+}
+
+func (s *PostgresStorage) SetAcceptedAnswer(questionID, answerID uint64) error {
+	const op = "storage.postgres.SetAcceptedAnswer"
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var q pgdto.QuestionDTO
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&q, questionID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return qa.ErrQuestionNotFound
+			}
+			return fmt.Errorf("%w: %w", ErrAcceptAnswer, err)
+		}
+
+		var prev uint64
+		if q.AcceptedAnswerID != nil {
+			prev = *q.AcceptedAnswerID
+		}
+		if prev == answerID {
+			return nil
+		}
+
+		if err := tx.Model(&q).Update("accepted_answer_id", answerID).Error; err != nil {
+			return fmt.Errorf("%w: %w", ErrAcceptAnswer, err)
+		}
+
+		if prev != 0 {
+			if err := creditAccepted(tx, q, prev, -1); err != nil {
+				return err
+			}
+		}
+		return creditAccepted(tx, q, answerID, 1)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) SetDuplicate(id, canonicalID uint64) error {
+	const op = "storage.postgres.SetDuplicate"
+
+	var canonical *uint64
+	if canonicalID != 0 {
+		canonical = &canonicalID
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&pgdto.QuestionDTO{}).
+			Where("id = ?", id).
+			Update("duplicate_of_id", canonical)
+		if res.Error != nil {
+			return fmt.Errorf("%w: %w", ErrSetDuplicate, res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return qa.ErrQuestionNotFound
+		}
+
+		if canonical == nil {
+			return nil
+		}
+		if err := tx.Unscoped().Model(&pgdto.QuestionDTO{}).
+			Where("duplicate_of_id = ?", id).
+			Update("duplicate_of_id", canonicalID).Error; err != nil {
+
+			return fmt.Errorf("%w: %w", ErrSetDuplicate, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	return nil
@@ -145,11 +346,18 @@ func (s *PostgresStorage) GetAnswer(id uint64) (*qa.Answer, error) {
 	var dto pgdto.AnswerDTO
 
 	if err := s.db.First(&dto, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, qa.ErrAnswerNotFound)
+		}
 		return nil, fmt.Errorf("%s: %w: %w", op, ErrGetAnswer, err)
 	}
 
-	ans := pgdto.ToDomainAnswer(dto)
-	return &ans, nil
+	ans := []qa.Answer{pgdto.ToDomainAnswer(dto)}
+	if err := loadAuthorReputation(s.db, ans); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &ans[0], nil
 }
 
 func (s *PostgresStorage) DeleteAnswer(id uint64) error {
@@ -161,3 +369,51 @@ func (s *PostgresStorage) DeleteAnswer(id uint64) error {
 
 	return nil
 }
+
+func (s *PostgresStorage) GetQuestionsByUser(userID uint64) ([]qa.Question, error) {
+	const op = "storage.postgres.GetQuestionsByUser"
+
+	var dtos []pgdto.QuestionDTO
+
+	if err := s.db.Where("user_id = ?", userID).
+		Order("id DESC").
+		Find(&dtos).Error; err != nil {
+
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrGetUserActivity, err)
+	}
+
+	res := make([]qa.Question, len(dtos))
+	for i := range dtos {
+		res[i] = pgdto.ToDomainQuestion(dtos[i])
+	}
+
+	if err := loadTags(s.db, res); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return res, nil
+}
+
+func (s *PostgresStorage) GetAnswersByUser(userID uint64) ([]qa.Answer, error) {
+	const op = "storage.postgres.GetAnswersByUser"
+
+	var dtos []pgdto.AnswerDTO
+
+	if err := s.db.Where("user_id = ?", userID).
+		Order("id DESC").
+		Find(&dtos).Error; err != nil {
+
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrGetUserActivity, err)
+	}
+
+	res := make([]qa.Answer, len(dtos))
+	for i := range dtos {
+		res[i] = pgdto.ToDomainAnswer(dtos[i])
+	}
+
+	if err := loadAuthorReputation(s.db, res); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return res, nil
+}
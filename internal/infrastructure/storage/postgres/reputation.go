@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"question-answer/internal/domain/qa"
+	"question-answer/internal/infrastructure/storage/postgres/dto"
+)
+
+var ErrReputation = errors.New("failed to update reputation")
+
+func (s *PostgresStorage) GetReputation(userID uint64) (int64, error) {
+	const op = "storage.postgres.GetReputation"
+
+	var reputation int64
+	if err := s.db.Raw("SELECT reputation FROM users WHERE id = ?", userID).
+		Scan(&reputation).Error; err != nil {
+
+		return 0, fmt.Errorf("%s: %w: %w", op, ErrReputation, err)
+	}
+
+	return reputation, nil
+}
+
+func (s *PostgresStorage) RecalculateReputation() (int64, error) {
+	const op = "storage.postgres.RecalculateReputation"
+
+	res := s.db.Exec(`
+		UPDATE users SET reputation = totals.total
+		FROM (
+			SELECT u.id, COALESCE(SUM(e.points), 0) AS total
+			FROM users u
+			LEFT JOIN reputation_events e ON e.user_id = u.id
+			GROUP BY u.id
+		) totals
+		WHERE users.id = totals.id AND users.reputation <> totals.total`)
+	if res.Error != nil {
+		return 0, fmt.Errorf("%s: %w: %w", op, ErrReputation, res.Error)
+	}
+
+	return res.RowsAffected, nil
+}
+
+// appendReputation adds the entry to the ledger and to the user's total.
+func appendReputation(tx *gorm.DB, e qa.ReputationEntry) error {
+	dto := pgdto.ToDTOReputationEvent(e)
+	if err := tx.Create(&dto).Error; err != nil {
+		return fmt.Errorf("%w: %w", ErrReputation, err)
+	}
+
+	if err := tx.Exec("UPDATE users SET reputation = reputation + ? WHERE id = ?", e.Points, e.UserID).Error; err != nil {
+		return fmt.Errorf("%w: %w", ErrReputation, err)
+	}
+
+	return nil
+}
+
+// creditAccepted records the reputation for the answer becoming (sign 1) or
+// ceasing to be (sign -1) the accepted one. Askers accepting their own
+// answer earn nothing, and purged answers have nobody left to charge.
+func creditAccepted(tx *gorm.DB, q pgdto.QuestionDTO, answerID uint64, sign int64) error {
+	var a pgdto.AnswerDTO
+	if err := tx.Unscoped().Select("id", "user_id").First(&a, answerID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("%w: %w", ErrReputation, err)
+	}
+	if q.UserID != nil && *q.UserID == a.UserID {
+		return nil
+	}
+
+	var actorID uint64
+	if q.UserID != nil {
+		actorID = *q.UserID
+	}
+
+	return appendReputation(tx, qa.ReputationEntry{
+		UserID:     a.UserID,
+		Event:      qa.EventAnswerAccepted,
+		Points:     sign * qa.EventAnswerAccepted.Points(),
+		QuestionID: q.ID,
+		AnswerID:   a.ID,
+		ActorID:    actorID,
+	})
+}
+
+// loadAuthorReputation fills in the reputation of the answers' authors.
+func loadAuthorReputation(db *gorm.DB, answers []qa.Answer) error {
+	if len(answers) == 0 {
+		return nil
+	}
+
+	ids := make([]uint64, len(answers))
+	for i := range answers {
+		ids[i] = answers[i].UserID
+	}
+
+	var rows []struct {
+		ID         uint64
+		Reputation int64
+	}
+	if err := db.Table("users").
+		Select("id, reputation").
+		Where("id IN ?", ids).
+		Scan(&rows).Error; err != nil {
+
+		return fmt.Errorf("%w: %w", ErrReputation, err)
+	}
+
+	byUser := make(map[uint64]int64, len(rows))
+	for _, row := range rows {
+		byUser[row.ID] = row.Reputation
+	}
+	for i := range answers {
+		answers[i].AuthorReputation = byUser[answers[i].UserID]
+	}
+
+	return nil
+}
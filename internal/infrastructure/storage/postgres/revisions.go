@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"question-answer/internal/domain/qa"
+	"question-answer/internal/infrastructure/storage/postgres/dto"
+)
+
+var (
+	ErrUpdateQuestion = errors.New("failed to update question")
+	ErrUpdateAnswer   = errors.New("failed to update answer")
+	ErrGetRevision    = errors.New("failed to get revision")
+)
+
+func (s *PostgresStorage) UpdateQuestion(id, editorID uint64, p qa.QuestionPatch) error {
+	const op = "storage.postgres.UpdateQuestion"
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var q pgdto.QuestionDTO
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&q, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return qa.ErrQuestionNotFound
+			}
+			return fmt.Errorf("%w: %w", ErrUpdateQuestion, err)
+		}
+
+		if p.Empty() {
+			return nil
+		}
+
+		if err := tx.Create(&pgdto.RevisionDTO{
+			QuestionID:    &q.ID,
+			EditorID:      &editorID,
+			PreviousTitle: q.Title,
+			PreviousText:  q.Body,
+		}).Error; err != nil {
+			return fmt.Errorf("%w: %w", ErrUpdateQuestion, err)
+		}
+
+		changes := make(map[string]any, 2)
+		if p.Title != nil {
+			changes["title"] = *p.Title
+		}
+		if p.Body != nil {
+			changes["body"] = *p.Body
+		}
+		if err := tx.Model(&q).Updates(changes).Error; err != nil {
+			return fmt.Errorf("%w: %w", ErrUpdateQuestion, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) UpdateAnswer(id, editorID uint64, p qa.AnswerPatch) error {
+	const op = "storage.postgres.UpdateAnswer"
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var a pgdto.AnswerDTO
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&a, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return qa.ErrAnswerNotFound
+			}
+			return fmt.Errorf("%w: %w", ErrUpdateAnswer, err)
+		}
+
+		if p.Text == nil {
+			return nil
+		}
+
+		if err := tx.Create(&pgdto.RevisionDTO{
+			AnswerID:     &a.ID,
+			EditorID:     &editorID,
+			PreviousText: a.Text,
+		}).Error; err != nil {
+			return fmt.Errorf("%w: %w", ErrUpdateAnswer, err)
+		}
+
+		if err := tx.Model(&a).Update("text", *p.Text).Error; err != nil {
+			return fmt.Errorf("%w: %w", ErrUpdateAnswer, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) GetRevision(id uint64) (*qa.Revision, error) {
+	const op = "storage.postgres.GetRevision"
+
+	var dto pgdto.RevisionDTO
+
+	if err := s.db.First(&dto, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, qa.ErrRevisionNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrGetRevision, err)
+	}
+
+	rev := pgdto.ToDomainRevision(dto)
+	return &rev, nil
+}
+
+func (s *PostgresStorage) GetQuestionRevisions(questionID uint64) ([]qa.Revision, error) {
+	const op = "storage.postgres.GetQuestionRevisions"
+
+	revs, err := s.findRevisions(s.db.Where("question_id = ?", questionID))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return revs, nil
+}
+
+func (s *PostgresStorage) GetAnswerRevisions(answerID uint64) ([]qa.Revision, error) {
+	const op = "storage.postgres.GetAnswerRevisions"
+
+	revs, err := s.findRevisions(s.db.Where("answer_id = ?", answerID))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return revs, nil
+}
+
+func (s *PostgresStorage) findRevisions(query *gorm.DB) ([]qa.Revision, error) {
+	var dtos []pgdto.RevisionDTO
+
+	if err := query.Order("id DESC").Find(&dtos).Error; err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrGetRevision, err)
+	}
+
+	res := make([]qa.Revision, len(dtos))
+	for i := range dtos {
+		res[i] = pgdto.ToDomainRevision(dtos[i])
+	}
+
+	return res, nil
+}
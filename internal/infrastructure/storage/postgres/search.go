@@ -0,0 +1,182 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"question-answer/internal/domain/qa"
+)
+
+var (
+	ErrSearch         = errors.New("failed to search")
+	ErrSearchLanguage = errors.New("failed to set search language")
+)
+
+// headlineOptions configures ts_headline: a couple of short fragments around
+// the matches, with the matched terms marked for the caller.
+var headlineOptions = fmt.Sprintf(
+	"StartSel=\"%s\", StopSel=\"%s\", MaxWords=30, MinWords=10, MaxFragments=2, FragmentDelimiter=\" … \"",
+	qa.SnippetMatchStart, qa.SnippetMatchStop,
+)
+
+func (s *PostgresStorage) Search(f qa.SearchFilter, limit int) ([]qa.SearchHit, error) {
+	const op = "storage.postgres.Search"
+
+	// Without text there is nothing to rank by or to highlight, and
+	// to_tsquery of nothing matches nothing.
+	var tsquery any
+	questionRank, answerRank := gorm.Expr("0::real"), gorm.Expr("0::real")
+	snippet := gorm.Expr("left(hits.document, 200)")
+	if f.Text != "" {
+		// The query is parsed with the configuration every row is indexed
+		// with. Its arguments are constants, so the planner folds it into
+		// one tsquery the GIN indexes can serve.
+		tsquery = gorm.Expr("websearch_to_tsquery(?::regconfig, ?)", s.searchConfig, f.Text)
+		questionRank = gorm.Expr("ts_rank(questions.search_vector, ?)", tsquery)
+		answerRank = gorm.Expr("ts_rank(answers.search_vector, ?)", tsquery)
+		snippet = gorm.Expr("ts_headline(?::regconfig, hits.document, ?, ?)", s.searchConfig, tsquery, headlineOptions)
+	}
+
+	var branches []any
+	if f.Target != qa.SearchAnswers {
+		query := s.db.Table("questions").
+			Select(`questions.id AS question_id, 0 AS answer_id, questions.title,
+				questions.title || E'\n' || questions.body AS document,
+				? AS rank, questions.created_at`, questionRank).
+			Where("questions.deleted_at IS NULL")
+		if tsquery != nil {
+			query = query.Where("questions.search_vector @@ ?", tsquery)
+		}
+		branches = append(branches, whereQuestionFilter(query, f.Filter))
+	}
+	if f.Target != qa.SearchQuestions {
+		// The author and the creation time range are the answer's, the
+		// rest of the filter applies to its question.
+		questionFilter := f.Filter
+		questionFilter.AuthorID, questionFilter.CreatedFrom, questionFilter.CreatedTo = 0, nil, nil
+
+		query := s.db.Table("answers").
+			Select(`answers.question_id, answers.id AS answer_id, questions.title,
+				answers.text AS document,
+				? AS rank, answers.created_at`, answerRank).
+			Joins("JOIN questions ON questions.id = answers.question_id").
+			Where("answers.deleted_at IS NULL AND questions.deleted_at IS NULL")
+		if tsquery != nil {
+			query = query.Where("answers.search_vector @@ ?", tsquery)
+		}
+		if f.Filter.AuthorID != 0 {
+			query = query.Where("answers.user_id = ?", f.Filter.AuthorID)
+		}
+		if f.Filter.CreatedFrom != nil {
+			query = query.Where("answers.created_at >= ?", *f.Filter.CreatedFrom)
+		}
+		if f.Filter.CreatedTo != nil {
+			query = query.Where("answers.created_at < ?", *f.Filter.CreatedTo)
+		}
+		branches = append(branches, whereQuestionFilter(query, questionFilter))
+	}
+	union := strings.TrimSuffix(strings.Repeat("? UNION ALL ", len(branches)), " UNION ALL ")
+
+	// Hits are ranked and cut to limit before the costly headlines are
+	// built.
+	var rows []struct {
+		QuestionID uint64
+		AnswerID   uint64
+		Title      string
+		Snippet    string
+		Rank       float64
+		CreatedAt  time.Time
+	}
+	err := s.db.Raw(`
+		SELECT hits.question_id, hits.answer_id, hits.title, hits.rank, hits.created_at,
+			? AS snippet
+		FROM (?
+			ORDER BY rank DESC, created_at DESC
+			LIMIT ?
+		) hits
+		ORDER BY hits.rank DESC, hits.created_at DESC`,
+		snippet, gorm.Expr(union, branches...), limit,
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrSearch, err)
+	}
+
+	hits := make([]qa.SearchHit, 0, len(rows))
+	for _, row := range rows {
+		hits = append(hits, qa.SearchHit{
+			QuestionID: row.QuestionID,
+			AnswerID:   row.AnswerID,
+			Title:      row.Title,
+			Snippet:    row.Snippet,
+			Rank:       row.Rank,
+			CreatedAt:  row.CreatedAt,
+		})
+	}
+
+	return hits, nil
+}
+
+// setSearchLanguage makes language, the name of a Postgres text search
+// configuration, the one new questions and answers are indexed with, and
+// reindexes the rows indexed with another. It returns the name Postgres
+// knows the configuration by. An empty language keeps the current one.
+func setSearchLanguage(db *gorm.DB, language string) (string, error) {
+	tables := []string{"questions", "answers"}
+
+	defaults := make([]string, len(tables))
+	for i, table := range tables {
+		err := db.Raw(`SELECT column_default FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name = ? AND column_name = 'search_config'`, table).
+			Scan(&defaults[i]).Error
+		if err != nil {
+			return "", fmt.Errorf("%w: %w", ErrSearchLanguage, err)
+		}
+	}
+
+	var name string
+	if language == "" {
+		// The default is an expression Postgres printed itself, such as
+		// 'english'::regconfig.
+		if err := db.Raw("SELECT (" + defaults[0] + ")::text").Scan(&name).Error; err != nil {
+			return "", fmt.Errorf("%w: %w", ErrSearchLanguage, err)
+		}
+		return name, nil
+	}
+
+	if err := db.Raw("SELECT ?::regconfig::text", language).Scan(&name).Error; err != nil {
+		return "", fmt.Errorf("%w: %q: %w", ErrSearchLanguage, language, err)
+	}
+	// Column defaults cannot be bound parameters. The name has just been
+	// resolved by Postgres, so quoting it as a literal is enough.
+	literal := "'" + strings.ReplaceAll(name, "'", "''") + "'::regconfig"
+
+	// Posts only get their configuration from the default, and the rows
+	// were reindexed when it was last changed. An unchanged language thus
+	// needs no ALTER TABLE and its lock on every startup.
+	stale := slices.ContainsFunc(defaults, func(d string) bool { return d != literal })
+	if !stale {
+		return name, nil
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, table := range tables {
+			if err := tx.Exec("ALTER TABLE " + table + " ALTER COLUMN search_config SET DEFAULT " + literal).Error; err != nil {
+				return fmt.Errorf("%w: %w", ErrSearchLanguage, err)
+			}
+			if err := tx.Exec("UPDATE "+table+" SET search_config = ?::regconfig WHERE search_config <> ?::regconfig", name, name).Error; err != nil {
+				return fmt.Errorf("%w: %w", ErrSearchLanguage, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
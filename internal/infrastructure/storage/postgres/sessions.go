@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	auth "question-answer/internal/domain/users"
+	"question-answer/internal/infrastructure/storage/postgres/dto"
+)
+
+var (
+	ErrCreateSession   = errors.New("failed to create session")
+	ErrGetSession      = errors.New("failed to get session")
+	ErrRevokeSession   = errors.New("failed to revoke session")
+	ErrAddRefreshToken = errors.New("failed to add refresh token")
+	ErrGetRefreshToken = errors.New("failed to get refresh token")
+	ErrUseRefreshToken = errors.New("failed to mark refresh token used")
+)
+
+func (s *PostgresStorage) CreateSession(sess auth.Session) error {
+	const op = "storage.postgres.CreateSession"
+
+	dto := pgdto.ToDTOSession(sess)
+
+	if err := s.db.Create(&dto).Error; err != nil {
+		return fmt.Errorf("%s: %w: %w", op, ErrCreateSession, err)
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) GetSession(id string) (*auth.Session, error) {
+	const op = "storage.postgres.GetSession"
+
+	var dto pgdto.SessionDTO
+
+	if err := s.db.Where("id = ?", id).First(&dto).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, auth.ErrSessionNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrGetSession, err)
+	}
+
+	sess := pgdto.ToDomainSession(dto)
+	return &sess, nil
+}
+
+func (s *PostgresStorage) RevokeSession(id string, at time.Time) error {
+	const op = "storage.postgres.RevokeSession"
+
+	if err := s.db.Model(&pgdto.SessionDTO{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at).Error; err != nil {
+
+		return fmt.Errorf("%s: %w: %w", op, ErrRevokeSession, err)
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) RevokeUserSessions(userID uint64, at time.Time) error {
+	const op = "storage.postgres.RevokeUserSessions"
+
+	if err := s.db.Model(&pgdto.SessionDTO{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", at).Error; err != nil {
+
+		return fmt.Errorf("%s: %w: %w", op, ErrRevokeSession, err)
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) AddRefreshToken(t auth.RefreshToken) error {
+	const op = "storage.postgres.AddRefreshToken"
+
+	dto := pgdto.ToDTORefreshToken(t)
+
+	if err := s.db.Create(&dto).Error; err != nil {
+		return fmt.Errorf("%s: %w: %w", op, ErrAddRefreshToken, err)
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) GetRefreshToken(hash string) (*auth.RefreshToken, error) {
+	const op = "storage.postgres.GetRefreshToken"
+
+	var dto pgdto.RefreshTokenDTO
+
+	if err := s.db.Where("token_hash = ?", hash).First(&dto).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, auth.ErrInvalidRefreshToken)
+		}
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrGetRefreshToken, err)
+	}
+
+	t := pgdto.ToDomainRefreshToken(dto)
+	return &t, nil
+}
+
+func (s *PostgresStorage) MarkRefreshTokenUsed(hash string, at time.Time) (bool, error) {
+	const op = "storage.postgres.MarkRefreshTokenUsed"
+
+	res := s.db.Model(&pgdto.RefreshTokenDTO{}).
+		Where("token_hash = ? AND used_at IS NULL", hash).
+		Update("used_at", at)
+	if res.Error != nil {
+		return false, fmt.Errorf("%s: %w: %w", op, ErrUseRefreshToken, res.Error)
+	}
+
+	return res.RowsAffected == 1, nil
+}
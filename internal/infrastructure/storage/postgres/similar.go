@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+
+	"question-answer/internal/domain/qa"
+	"question-answer/internal/infrastructure/storage/postgres/dto"
+)
+
+var ErrSimilarQuestions = errors.New("failed to find similar questions")
+
+func (s *PostgresStorage) SimilarQuestions(text string, excludeID uint64, limit int) ([]qa.SimilarQuestion, error) {
+	const op = "storage.postgres.SimilarQuestions"
+
+	// The text is short, like a title, so it is matched against whole
+	// titles but against any part of a body: % keeps titles above
+	// pg_trgm.similarity_threshold, <% bodies with a stretch above
+	// pg_trgm.word_similarity_threshold. The trigram indexes serve both.
+	var rows []struct {
+		ID               uint64
+		Title            string
+		AnswerCount      int64
+		AcceptedAnswerID *uint64
+		Score            float64
+	}
+	err := s.db.Model(&pgdto.QuestionDTO{}).
+		Select("questions.id, questions.title, questions.accepted_answer_id, "+
+			answerCountSQL+" AS answer_count, "+
+			"GREATEST(similarity(questions.title, ?), word_similarity(?, questions.body)) AS score", text, text).
+		Where("(questions.title % ? OR ? <% questions.body)", text, text).
+		Where("questions.id <> ? AND questions.duplicate_of_id IS NULL", excludeID).
+		Order("score DESC").
+		Order("questions.id DESC").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrSimilarQuestions, err)
+	}
+
+	res := make([]qa.SimilarQuestion, 0, len(rows))
+	for _, row := range rows {
+		var acceptedID uint64
+		if row.AcceptedAnswerID != nil {
+			acceptedID = *row.AcceptedAnswerID
+		}
+		res = append(res, qa.SimilarQuestion{
+			ID:               row.ID,
+			Title:            row.Title,
+			AnswerCount:      row.AnswerCount,
+			AcceptedAnswerID: acceptedID,
+			Score:            row.Score,
+		})
+	}
+
+	return res, nil
+}
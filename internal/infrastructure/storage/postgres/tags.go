@@ -0,0 +1,193 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"question-answer/internal/domain/qa"
+	"question-answer/internal/infrastructure/storage/postgres/dto"
+)
+
+var (
+	ErrListTags   = errors.New("failed to list tags")
+	ErrAddSynonym = errors.New("failed to add tag synonym")
+	ErrAttachTags = errors.New("failed to attach tags")
+	ErrLoadTags   = errors.New("failed to load tags")
+)
+
+func (s *PostgresStorage) ListTags() ([]qa.Tag, error) {
+	const op = "storage.postgres.ListTags"
+
+	var rows []struct {
+		ID    uint64
+		Name  string
+		Count int64
+	}
+	if err := s.db.Table("tags").
+		Select("tags.id, tags.name, COUNT(questions.id) AS count").
+		Joins("LEFT JOIN question_tags ON question_tags.tag_id = tags.id").
+		Joins("LEFT JOIN questions ON questions.id = question_tags.question_id AND questions.deleted_at IS NULL").
+		Group("tags.id").
+		Order("count DESC, tags.name ASC").
+		Scan(&rows).Error; err != nil {
+
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrListTags, err)
+	}
+
+	var synonyms []pgdto.TagSynonymDTO
+	if err := s.db.Order("synonym ASC").Find(&synonyms).Error; err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrListTags, err)
+	}
+	byTag := make(map[uint64][]string, len(synonyms))
+	for _, syn := range synonyms {
+		byTag[syn.TagID] = append(byTag[syn.TagID], syn.Synonym)
+	}
+
+	tags := make([]qa.Tag, len(rows))
+	for i, row := range rows {
+		tags[i] = qa.Tag{
+			ID:       row.ID,
+			Name:     row.Name,
+			Count:    row.Count,
+			Synonyms: byTag[row.ID],
+		}
+	}
+
+	return tags, nil
+}
+
+func (s *PostgresStorage) AddTagSynonym(tag, synonym string) error {
+	const op = "storage.postgres.AddTagSynonym"
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		tagID, err := resolveTag(tx, tag)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrAddSynonym, err)
+		}
+		if tagID == 0 {
+			return qa.ErrTagNotFound
+		}
+
+		// A synonym must not shadow a tag that is already in use.
+		var clash int64
+		if err := tx.Model(&pgdto.TagDTO{}).Where("name = ?", synonym).Count(&clash).Error; err != nil {
+			return fmt.Errorf("%w: %w", ErrAddSynonym, err)
+		}
+		if clash > 0 {
+			return qa.ErrTagExists
+		}
+
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&pgdto.TagSynonymDTO{Synonym: synonym, TagID: tagID})
+		if res.Error != nil {
+			return fmt.Errorf("%w: %w", ErrAddSynonym, res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return qa.ErrTagExists
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// resolveTag returns the id of the tag called name, following synonyms. Zero
+// means no such tag.
+func resolveTag(tx *gorm.DB, name string) (uint64, error) {
+	var id uint64
+	err := tx.Raw(`
+		SELECT id FROM tags WHERE name = ?
+		UNION ALL
+		SELECT tag_id FROM tag_synonyms WHERE synonym = ?
+		LIMIT 1`, name, name).
+		Scan(&id).Error
+	return id, err
+}
+
+// attachTags links the question to the named tags, creating tags that do
+// not exist yet. Names that are synonyms link to their tag.
+func attachTags(tx *gorm.DB, questionID uint64, names []string) error {
+	for _, name := range names {
+		tagID, err := resolveTag(tx, name)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrAttachTags, err)
+		}
+
+		if tagID == 0 {
+			// DO UPDATE rather than DO NOTHING so RETURNING yields the id
+			// when a concurrent request created the tag first.
+			if err := tx.Raw(`
+				INSERT INTO tags (name) VALUES (?)
+				ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+				RETURNING id`, name).
+				Scan(&tagID).Error; err != nil {
+
+				return fmt.Errorf("%w: %w", ErrAttachTags, err)
+			}
+		}
+
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&pgdto.QuestionTagDTO{QuestionID: questionID, TagID: tagID}).Error; err != nil {
+
+			return fmt.Errorf("%w: %w", ErrAttachTags, err)
+		}
+	}
+
+	return nil
+}
+
+// loadTags fills in the tag names of questions.
+func loadTags(db *gorm.DB, questions []qa.Question) error {
+	if len(questions) == 0 {
+		return nil
+	}
+
+	ids := make([]uint64, len(questions))
+	for i := range questions {
+		ids[i] = questions[i].ID
+	}
+
+	var rows []struct {
+		QuestionID uint64
+		Name       string
+	}
+	if err := db.Table("question_tags").
+		Select("question_tags.question_id, tags.name").
+		Joins("JOIN tags ON tags.id = question_tags.tag_id").
+		Where("question_tags.question_id IN ?", ids).
+		Order("tags.name ASC").
+		Scan(&rows).Error; err != nil {
+
+		return fmt.Errorf("%w: %w", ErrLoadTags, err)
+	}
+
+	byQuestion := make(map[uint64][]string, len(questions))
+	for _, row := range rows {
+		byQuestion[row.QuestionID] = append(byQuestion[row.QuestionID], row.Name)
+	}
+	for i := range questions {
+		questions[i].Tags = byQuestion[questions[i].ID]
+	}
+
+	return nil
+}
+
+// whereTags restricts query to questions carrying every tag, by name or
+// synonym.
+func whereTags(query *gorm.DB, tags []string) *gorm.DB {
+	for _, tag := range tags {
+		query = query.Where(`EXISTS (
+			SELECT 1 FROM question_tags qt
+			JOIN tags t ON t.id = qt.tag_id
+			WHERE qt.question_id = questions.id
+			  AND (t.name = ? OR t.id IN (SELECT tag_id FROM tag_synonyms WHERE synonym = ?)))`, tag, tag)
+	}
+	return query
+}
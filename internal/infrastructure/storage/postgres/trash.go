@@ -0,0 +1,181 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"question-answer/internal/domain/qa"
+	"question-answer/internal/infrastructure/storage/postgres/dto"
+)
+
+var (
+	ErrRestore   = errors.New("failed to restore")
+	ErrListTrash = errors.New("failed to list trash")
+	ErrPurge     = errors.New("failed to purge trash")
+)
+
+func (s *PostgresStorage) GetDeletedQuestion(id uint64) (*qa.Question, error) {
+	const op = "storage.postgres.GetDeletedQuestion"
+
+	var dto pgdto.QuestionDTO
+
+	if err := s.db.Unscoped().Where("deleted_at IS NOT NULL").First(&dto, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, qa.ErrQuestionNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrGetQuestion, err)
+	}
+
+	q := pgdto.ToDomainQuestion(dto)
+	return &q, nil
+}
+
+func (s *PostgresStorage) GetDeletedAnswer(id uint64) (*qa.Answer, error) {
+	const op = "storage.postgres.GetDeletedAnswer"
+
+	var dto pgdto.AnswerDTO
+
+	if err := s.db.Unscoped().Where("deleted_at IS NOT NULL").First(&dto, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, qa.ErrAnswerNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrGetAnswer, err)
+	}
+
+	a := pgdto.ToDomainAnswer(dto)
+	return &a, nil
+}
+
+// RestoreQuestion brings back the question together with the answers that
+// were deleted with it.
+func (s *PostgresStorage) RestoreQuestion(id uint64) error {
+	const op = "storage.postgres.RestoreQuestion"
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var q pgdto.QuestionDTO
+		if err := tx.Unscoped().Where("deleted_at IS NOT NULL").First(&q, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return qa.ErrQuestionNotFound
+			}
+			return fmt.Errorf("%w: %w", ErrRestore, err)
+		}
+
+		if err := tx.Unscoped().Model(&pgdto.AnswerDTO{}).
+			Where("question_id = ? AND deleted_at = ?", id, q.DeletedAt.Time).
+			Update("deleted_at", nil).Error; err != nil {
+
+			return fmt.Errorf("%w: %w", ErrRestore, err)
+		}
+
+		if err := tx.Unscoped().Model(&q).Update("deleted_at", nil).Error; err != nil {
+			return fmt.Errorf("%w: %w", ErrRestore, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) RestoreAnswer(id uint64) error {
+	const op = "storage.postgres.RestoreAnswer"
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var a pgdto.AnswerDTO
+		if err := tx.Unscoped().Where("deleted_at IS NOT NULL").First(&a, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return qa.ErrAnswerNotFound
+			}
+			return fmt.Errorf("%w: %w", ErrRestore, err)
+		}
+
+		var live int64
+		if err := tx.Model(&pgdto.QuestionDTO{}).Where("id = ?", a.QuestionID).Count(&live).Error; err != nil {
+			return fmt.Errorf("%w: %w", ErrRestore, err)
+		}
+		if live == 0 {
+			return qa.ErrQuestionDeleted
+		}
+
+		if err := tx.Unscoped().Model(&a).Update("deleted_at", nil).Error; err != nil {
+			return fmt.Errorf("%w: %w", ErrRestore, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) ListTrash() (*qa.Trash, error) {
+	const op = "storage.postgres.ListTrash"
+
+	var qdtos []pgdto.QuestionDTO
+	if err := s.db.Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC, id DESC").
+		Find(&qdtos).Error; err != nil {
+
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrListTrash, err)
+	}
+
+	var adtos []pgdto.AnswerDTO
+	if err := s.db.Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC, id DESC").
+		Find(&adtos).Error; err != nil {
+
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrListTrash, err)
+	}
+
+	trash := &qa.Trash{
+		Questions: make([]qa.Question, len(qdtos)),
+		Answers:   make([]qa.Answer, len(adtos)),
+	}
+	for i := range qdtos {
+		trash.Questions[i] = pgdto.ToDomainQuestion(qdtos[i])
+	}
+	for i := range adtos {
+		trash.Answers[i] = pgdto.ToDomainAnswer(adtos[i])
+	}
+
+	return trash, nil
+}
+
+// PurgeDeleted permanently removes questions and answers deleted before the
+// cutoff. Comments, votes, tags and revisions go with them by cascade.
+func (s *PostgresStorage) PurgeDeleted(before time.Time) (int64, error) {
+	const op = "storage.postgres.PurgeDeleted"
+
+	var purged int64
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Unscoped().Where("deleted_at < ?", before).Delete(&pgdto.AnswerDTO{})
+		if res.Error != nil {
+			return res.Error
+		}
+		purged += res.RowsAffected
+
+		res = tx.Unscoped().Where("deleted_at < ?", before).Delete(&pgdto.QuestionDTO{})
+		if res.Error != nil {
+			return res.Error
+		}
+		purged += res.RowsAffected
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w: %w", op, ErrPurge, err)
+	}
+
+	return purged, nil
+}
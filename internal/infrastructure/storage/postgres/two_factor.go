@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	auth "question-answer/internal/domain/users"
+	"question-answer/internal/infrastructure/storage/postgres/dto"
+)
+
+var (
+	ErrUpdateTOTP      = errors.New("failed to update two-factor settings")
+	ErrUseRecoveryCode = errors.New("failed to use recovery code")
+)
+
+func (s *PostgresStorage) SetTOTPSecret(userID uint64, secret string) error {
+	const op = "storage.postgres.SetTOTPSecret"
+
+	res := s.db.Model(&pgdto.UserDTO{}).
+		Where("id = ? AND NOT totp_enabled", userID).
+		Updates(map[string]any{"totp_secret": secret, "totp_last_step": 0})
+	if res.Error != nil {
+		return fmt.Errorf("%s: %w: %w", op, ErrUpdateTOTP, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, auth.ErrTOTPAlreadyEnabled)
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) EnableTOTP(userID uint64, recoveryCodeHashes []string) error {
+	const op = "storage.postgres.EnableTOTP"
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&pgdto.UserDTO{}).
+			Where("id = ?", userID).
+			Update("totp_enabled", true).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("user_id = ?", userID).
+			Delete(&pgdto.RecoveryCodeDTO{}).Error; err != nil {
+			return err
+		}
+
+		codes := make([]pgdto.RecoveryCodeDTO, len(recoveryCodeHashes))
+		for i, h := range recoveryCodeHashes {
+			codes[i] = pgdto.RecoveryCodeDTO{UserID: userID, CodeHash: h}
+		}
+		return tx.Create(&codes).Error
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w: %w", op, ErrUpdateTOTP, err)
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) DisableTOTP(userID uint64) error {
+	const op = "storage.postgres.DisableTOTP"
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&pgdto.UserDTO{}).
+			Where("id = ?", userID).
+			Updates(map[string]any{
+				"totp_enabled":   false,
+				"totp_secret":    nil,
+				"totp_last_step": 0,
+			}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("user_id = ?", userID).
+			Delete(&pgdto.RecoveryCodeDTO{}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w: %w", op, ErrUpdateTOTP, err)
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) AdvanceTOTPStep(userID uint64, step int64) (bool, error) {
+	const op = "storage.postgres.AdvanceTOTPStep"
+
+	res := s.db.Model(&pgdto.UserDTO{}).
+		Where("id = ? AND totp_last_step < ?", userID, step).
+		Update("totp_last_step", step)
+	if res.Error != nil {
+		return false, fmt.Errorf("%s: %w: %w", op, ErrUpdateTOTP, res.Error)
+	}
+
+	return res.RowsAffected == 1, nil
+}
+
+func (s *PostgresStorage) UseRecoveryCode(userID uint64, hash string, at time.Time) (bool, error) {
+	const op = "storage.postgres.UseRecoveryCode"
+
+	res := s.db.Model(&pgdto.RecoveryCodeDTO{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, hash).
+		Update("used_at", at)
+	if res.Error != nil {
+		return false, fmt.Errorf("%s: %w: %w", op, ErrUseRecoveryCode, res.Error)
+	}
+
+	return res.RowsAffected == 1, nil
+}
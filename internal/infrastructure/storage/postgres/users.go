@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	auth "question-answer/internal/domain/users"
+	"question-answer/internal/infrastructure/storage/postgres/dto"
+)
+
+var (
+	ErrCreateUser   = errors.New("failed to create user")
+	ErrGetUser      = errors.New("failed to get user")
+	ErrGetUserRoles = errors.New("failed to get user roles")
+	ErrGrantRole    = errors.New("failed to grant role")
+	ErrRevokeRole   = errors.New("failed to revoke role")
+	ErrUpdateUser   = errors.New("failed to update user")
+)
+
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+)
+
+func (s *PostgresStorage) CreateUser(u auth.User) (*auth.User, error) {
+	const op = "storage.postgres.CreateUser"
+
+	dto := pgdto.ToDTOUser(u)
+
+	if err := s.db.Create(&dto).Error; err != nil {
+		if isUniqueViolation(err, "users_email_key") {
+			return nil, fmt.Errorf("%s: %w", op, auth.ErrEmailExists)
+		}
+		if isUniqueViolation(err, "users_username_key") {
+			return nil, fmt.Errorf("%s: %w", op, auth.ErrUserExists)
+		}
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrCreateUser, err)
+	}
+
+	domObj := pgdto.ToDomainUser(dto)
+	return &domObj, nil
+}
+
+func (s *PostgresStorage) GetUserByID(id uint64) (*auth.User, error) {
+	const op = "storage.postgres.GetUserByID"
+
+	var dto pgdto.UserDTO
+
+	if err := s.db.First(&dto, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, auth.ErrUserNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrGetUser, err)
+	}
+
+	u := pgdto.ToDomainUser(dto)
+	return &u, nil
+}
+
+func (s *PostgresStorage) GetUserByUsername(username string) (*auth.User, error) {
+	const op = "storage.postgres.GetUserByUsername"
+
+	var dto pgdto.UserDTO
+
+	if err := s.db.Where("username = ?", username).First(&dto).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, auth.ErrUserNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrGetUser, err)
+	}
+
+	u := pgdto.ToDomainUser(dto)
+	return &u, nil
+}
+
+func (s *PostgresStorage) GetUserByEmail(email string) (*auth.User, error) {
+	const op = "storage.postgres.GetUserByEmail"
+
+	var dto pgdto.UserDTO
+
+	if err := s.db.Where("email = ?", email).First(&dto).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, auth.ErrUserNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrGetUser, err)
+	}
+
+	u := pgdto.ToDomainUser(dto)
+	return &u, nil
+}
+
+func (s *PostgresStorage) UpdatePassword(userID uint64, passwordHash string) error {
+	const op = "storage.postgres.UpdatePassword"
+
+	res := s.db.Model(&pgdto.UserDTO{}).
+		Where("id = ?", userID).
+		Update("password_hash", passwordHash)
+	if res.Error != nil {
+		return fmt.Errorf("%s: %w: %w", op, ErrUpdateUser, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, auth.ErrUserNotFound)
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) GetUserRoles(userID uint64) ([]auth.Role, error) {
+	const op = "storage.postgres.GetUserRoles"
+
+	var dtos []pgdto.UserRoleDTO
+
+	if err := s.db.Where("user_id = ?", userID).Order("role ASC").Find(&dtos).Error; err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrGetUserRoles, err)
+	}
+
+	roles := make([]auth.Role, len(dtos))
+	for i := range dtos {
+		roles[i] = auth.Role(dtos[i].Role)
+	}
+
+	return roles, nil
+}
+
+func (s *PostgresStorage) GrantRole(userID uint64, role auth.Role, grantedBy uint64) error {
+	const op = "storage.postgres.GrantRole"
+
+	dto := pgdto.UserRoleDTO{
+		UserID:    userID,
+		Role:      string(role),
+		GrantedBy: &grantedBy,
+	}
+
+	if err := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&dto).Error; err != nil {
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("%s: %w", op, auth.ErrUserNotFound)
+		}
+		return fmt.Errorf("%s: %w: %w", op, ErrGrantRole, err)
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) RevokeRole(userID uint64, role auth.Role) error {
+	const op = "storage.postgres.RevokeRole"
+
+	if err := s.db.Where("user_id = ? AND role = ?", userID, string(role)).
+		Delete(&pgdto.UserRoleDTO{}).Error; err != nil {
+
+		return fmt.Errorf("%s: %w: %w", op, ErrRevokeRole, err)
+	}
+
+	return nil
+}
+
+// isUniqueViolation reports whether err is a unique violation, optionally
+// restricted to the named constraint.
+func isUniqueViolation(err error, constraint ...string) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) || pqErr.Code != pgUniqueViolation {
+		return false
+	}
+	return len(constraint) == 0 || pqErr.Constraint == constraint[0]
+}
+
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == pgForeignKeyViolation
+}
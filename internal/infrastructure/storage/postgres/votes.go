@@ -0,0 +1,186 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"question-answer/internal/domain/qa"
+	"question-answer/internal/infrastructure/storage/postgres/dto"
+)
+
+var ErrVote = errors.New("failed to update vote")
+
+// voteTarget names the tables behind votes on one kind of post.
+type voteTarget struct {
+	onAnswer bool
+	// posts holds the voted posts and their cached scores.
+	posts string
+	// votes holds one row per voter, referencing the post by column.
+	votes  string
+	column string
+}
+
+var (
+	answerVotes   = voteTarget{onAnswer: true, posts: "answers", votes: "votes", column: "answer_id"}
+	questionVotes = voteTarget{posts: "questions", votes: "question_votes", column: "question_id"}
+)
+
+func (s *PostgresStorage) SetVote(answerID, userID uint64, v qa.Vote) (int64, error) {
+	const op = "storage.postgres.SetVote"
+
+	score, err := s.changeVote(answerVotes, answerID, userID, func(tx *gorm.DB) error {
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "answer_id"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+		}).Create(&pgdto.VoteDTO{
+			AnswerID: answerID,
+			UserID:   userID,
+			Value:    int(v),
+		}).Error
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return score, nil
+}
+
+func (s *PostgresStorage) DeleteVote(answerID, userID uint64) (int64, error) {
+	const op = "storage.postgres.DeleteVote"
+
+	score, err := s.changeVote(answerVotes, answerID, userID, func(tx *gorm.DB) error {
+		return tx.Where("answer_id = ? AND user_id = ?", answerID, userID).
+			Delete(&pgdto.VoteDTO{}).Error
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return score, nil
+}
+
+func (s *PostgresStorage) SetQuestionVote(questionID, userID uint64, v qa.Vote) (int64, error) {
+	const op = "storage.postgres.SetQuestionVote"
+
+	score, err := s.changeVote(questionVotes, questionID, userID, func(tx *gorm.DB) error {
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "question_id"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+		}).Create(&pgdto.QuestionVoteDTO{
+			QuestionID: questionID,
+			UserID:     userID,
+			Value:      int(v),
+		}).Error
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return score, nil
+}
+
+func (s *PostgresStorage) DeleteQuestionVote(questionID, userID uint64) (int64, error) {
+	const op = "storage.postgres.DeleteQuestionVote"
+
+	score, err := s.changeVote(questionVotes, questionID, userID, func(tx *gorm.DB) error {
+		return tx.Where("question_id = ? AND user_id = ?", questionID, userID).
+			Delete(&pgdto.QuestionVoteDTO{}).Error
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return score, nil
+}
+
+// changeVote applies change, refreshes the denormalized score of the post
+// and records the reputation the change earns its author. The post row is
+// locked first so concurrent votes on it are serialized and each recount
+// sees the previous one.
+func (s *PostgresStorage) changeVote(t voteTarget, postID, userID uint64, change func(tx *gorm.DB) error) (int64, error) {
+	var score int64
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var post struct {
+			UserID *uint64
+		}
+		if err := tx.Table(t.posts).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("user_id").
+			Where("id = ? AND deleted_at IS NULL", postID).
+			Take(&post).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				if t.onAnswer {
+					return qa.ErrAnswerNotFound
+				}
+				return qa.ErrQuestionNotFound
+			}
+			return fmt.Errorf("%w: %w", ErrVote, err)
+		}
+
+		prev, err := currentVote(tx, t, postID, userID)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrVote, err)
+		}
+
+		if err := change(tx); err != nil {
+			return fmt.Errorf("%w: %w", ErrVote, err)
+		}
+
+		next, err := currentVote(tx, t, postID, userID)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrVote, err)
+		}
+
+		err = tx.Raw(`
+			UPDATE `+t.posts+`
+			SET score = (SELECT COALESCE(SUM(value), 0) FROM `+t.votes+` WHERE `+t.column+` = ?)
+			WHERE id = ?
+			RETURNING score`, postID, postID).
+			Scan(&score).Error
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrVote, err)
+		}
+
+		// Questions from before authorship have nobody to credit.
+		if post.UserID == nil {
+			return nil
+		}
+		event, points := qa.VoteReputation(t.onAnswer, prev, next)
+		if points == 0 {
+			return nil
+		}
+		entry := qa.ReputationEntry{
+			UserID:  *post.UserID,
+			Event:   event,
+			Points:  points,
+			ActorID: userID,
+		}
+		if t.onAnswer {
+			entry.AnswerID = postID
+		} else {
+			entry.QuestionID = postID
+		}
+		return appendReputation(tx, entry)
+	})
+
+	return score, err
+}
+
+// currentVote returns the user's vote on the post, zero if there is none.
+func currentVote(tx *gorm.DB, t voteTarget, postID, userID uint64) (qa.Vote, error) {
+	var values []int
+	if err := tx.Table(t.votes).
+		Where(t.column+" = ? AND user_id = ?", postID, userID).
+		Pluck("value", &values).Error; err != nil {
+
+		return 0, err
+	}
+	if len(values) == 0 {
+		return 0, nil
+	}
+	return qa.Vote(values[0]), nil
+}
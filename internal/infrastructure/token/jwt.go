@@ -0,0 +1,151 @@
+// Package token provides signed access tokens for authenticated users.
+package token
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	auth "question-answer/internal/domain/users"
+)
+
+var (
+	ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+	ErrMissingSecret        = errors.New("jwt secret is not set")
+	ErrLoadKey              = errors.New("failed to load signing key")
+)
+
+type Config struct {
+	Algorithm      string
+	Secret         string
+	PrivateKeyPath string
+	PublicKeyPath  string
+	Issuer         string
+	AccessTTL      time.Duration
+}
+
+type claims struct {
+	jwt.RegisteredClaims
+	Username  string   `json:"username"`
+	Roles     []string `json:"roles,omitempty"`
+	SessionID string   `json:"sid,omitempty"`
+}
+
+type JWTManager struct {
+	method    jwt.SigningMethod
+	signKey   any
+	verifyKey any
+	issuer    string
+	ttl       time.Duration
+	now       func() time.Time
+}
+
+func NewJWTManager(cfg Config) (*JWTManager, error) {
+	const op = "token.NewJWTManager"
+
+	m := &JWTManager{
+		issuer: cfg.Issuer,
+		ttl:    cfg.AccessTTL,
+		now:    time.Now,
+	}
+
+	switch cfg.Algorithm {
+	case "", jwt.SigningMethodHS256.Alg():
+		if cfg.Secret == "" {
+			return nil, fmt.Errorf("%s: %w", op, ErrMissingSecret)
+		}
+		m.method = jwt.SigningMethodHS256
+		m.signKey = []byte(cfg.Secret)
+		m.verifyKey = []byte(cfg.Secret)
+	case jwt.SigningMethodRS256.Alg():
+		priv, err := os.ReadFile(cfg.PrivateKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w: %w", op, ErrLoadKey, err)
+		}
+		pub, err := os.ReadFile(cfg.PublicKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w: %w", op, ErrLoadKey, err)
+		}
+		if m.signKey, err = jwt.ParseRSAPrivateKeyFromPEM(priv); err != nil {
+			return nil, fmt.Errorf("%s: %w: %w", op, ErrLoadKey, err)
+		}
+		if m.verifyKey, err = jwt.ParseRSAPublicKeyFromPEM(pub); err != nil {
+			return nil, fmt.Errorf("%s: %w: %w", op, ErrLoadKey, err)
+		}
+		m.method = jwt.SigningMethodRS256
+	default:
+		return nil, fmt.Errorf("%s: %w: %q", op, ErrUnsupportedAlgorithm, cfg.Algorithm)
+	}
+
+	return m, nil
+}
+
+func (m *JWTManager) Issue(p auth.Principal) (string, time.Time, error) {
+	const op = "token.JWTManager.Issue"
+
+	now := m.now()
+	exp := now.Add(m.ttl)
+
+	roles := make([]string, len(p.Roles))
+	for i, r := range p.Roles {
+		roles[i] = string(r)
+	}
+
+	t := jwt.NewWithClaims(m.method, claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    m.issuer,
+			Subject:   strconv.FormatUint(p.UserID, 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(exp),
+		},
+		Username:  p.Username,
+		Roles:     roles,
+		SessionID: p.SessionID,
+	})
+
+	signed, err := t.SignedString(m.signKey)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return signed, exp, nil
+}
+
+func (m *JWTManager) Parse(raw string) (*auth.Principal, error) {
+	const op = "token.JWTManager.Parse"
+
+	var c claims
+	_, err := jwt.ParseWithClaims(raw, &c,
+		func(*jwt.Token) (any, error) { return m.verifyKey, nil },
+		jwt.WithValidMethods([]string{m.method.Alg()}),
+		jwt.WithIssuer(m.issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(m.now),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, auth.ErrInvalidToken, err)
+	}
+
+	userID, err := strconv.ParseUint(c.Subject, 10, 64)
+	if err != nil || userID == 0 {
+		return nil, fmt.Errorf("%s: %w: bad subject %q", op, auth.ErrInvalidToken, c.Subject)
+	}
+
+	roles := make([]auth.Role, 0, len(c.Roles))
+	for _, name := range c.Roles {
+		if r, err := auth.ParseRole(name); err == nil {
+			roles = append(roles, r)
+		}
+	}
+
+	return &auth.Principal{
+		UserID:    userID,
+		Username:  c.Username,
+		Roles:     roles,
+		SessionID: c.SessionID,
+	}, nil
+}
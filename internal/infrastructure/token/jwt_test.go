@@ -0,0 +1,79 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+
+	auth "question-answer/internal/domain/users"
+)
+
+func newTestManager(t *testing.T) *JWTManager {
+	t.Helper()
+
+	m, err := NewJWTManager(Config{
+		Algorithm: "HS256",
+		Secret:    "test-secret",
+		Issuer:    "question-answer",
+		AccessTTL: time.Minute,
+	})
+	require.NoError(t, err)
+	return m
+}
+
+func TestJWTManagerRoundTrip(t *testing.T) {
+	m := newTestManager(t)
+
+	raw, exp, err := m.Issue(auth.Principal{
+		UserID:    42,
+		Username:  "gopher",
+		Roles:     []auth.Role{auth.RoleModerator, auth.RoleMember},
+		SessionID: "8d1c6f4e-2f1a-4b7e-9a53-0f7f1d2c3b4a",
+	})
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now().Add(time.Minute), exp, time.Second)
+
+	p, err := m.Parse(raw)
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), p.UserID)
+	require.Equal(t, "gopher", p.Username)
+	require.Equal(t, "8d1c6f4e-2f1a-4b7e-9a53-0f7f1d2c3b4a", p.SessionID)
+	require.Equal(t, []auth.Role{auth.RoleModerator, auth.RoleMember}, p.Roles)
+	require.True(t, p.HasPermission(auth.PermModerateContent))
+	require.False(t, p.HasPermission(auth.PermManageRoles))
+}
+
+func TestJWTManagerRejects(t *testing.T) {
+	m := newTestManager(t)
+
+	expired := newTestManager(t)
+	expired.now = func() time.Time { return time.Now().Add(-time.Hour) }
+	expiredToken, _, err := expired.Issue(auth.Principal{UserID: 1})
+	require.NoError(t, err)
+
+	other, err := NewJWTManager(Config{Secret: "another-secret", Issuer: "question-answer", AccessTTL: time.Minute})
+	require.NoError(t, err)
+	foreignToken, _, err := other.Issue(auth.Principal{UserID: 1})
+	require.NoError(t, err)
+
+	noneToken, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{
+		"sub": "1",
+		"iss": "question-answer",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	for name, raw := range map[string]string{
+		"expired":      expiredToken,
+		"wrong secret": foreignToken,
+		"alg none":     noneToken,
+		"garbage":      "not-a-token",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := m.Parse(raw)
+			require.ErrorIs(t, err, auth.ErrInvalidToken)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Package markdown renders user-written Markdown to HTML that is safe to
+// embed in a page: CommonMark plus GitHub tables, strikethrough and
+// autolinks, with the output passed through an allow-list sanitizer.
+package markdown
+
+import (
+	"bytes"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer/html"
+)
+
+var (
+	// Raw HTML is let through the renderer so harmless inline markup keeps
+	// working; the sanitizer is what removes scripts, iframes, event
+	// handlers and javascript: URLs.
+	md = goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithRendererOptions(html.WithUnsafe()),
+	)
+
+	policy = newPolicy()
+)
+
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.RequireNoFollowOnLinks(true)
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+	// Keep the fenced code language for client-side highlighting.
+	p.AllowAttrs("class").Matching(bluemonday.SpaceSeparatedTokens).OnElements("code")
+	return p
+}
+
+// Render converts src to sanitized HTML.
+func Render(src string) string {
+	if src == "" {
+		return ""
+	}
+
+	var buf bytes.Buffer
+	if err := md.Convert([]byte(src), &buf); err != nil {
+		// goldmark only fails when writing fails, and a bytes.Buffer does
+		// not.
+		return ""
+	}
+
+	return policy.Sanitize(buf.String())
+}
@@ -0,0 +1,39 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	out := Render("# Staging\n\nUse **pgx** and `sslmode=require`:\n\n```go\ndb.Ping()\n```\n")
+	require.Contains(t, out, "<h1>Staging</h1>")
+	require.Contains(t, out, "<strong>pgx</strong>")
+	require.Contains(t, out, "<code>sslmode=require</code>")
+	require.Contains(t, out, `<code class="language-go">`)
+
+	require.Empty(t, Render(""))
+}
+
+func TestRenderStripsActiveContent(t *testing.T) {
+	cases := map[string]string{
+		"script":        "hi <script>alert(1)</script>",
+		"iframe":        `<iframe src="https://evil.example"></iframe>`,
+		"event handler": `<img src="x.png" onerror="alert(1)">`,
+		"js link":       "[click](javascript:alert(1))",
+		"inline style":  `<p style="position:fixed" onclick="steal()">x</p>`,
+	}
+
+	for name, src := range cases {
+		t.Run(name, func(t *testing.T) {
+			out := Render(src)
+			require.NotContains(t, out, "<script")
+			require.NotContains(t, out, "<iframe")
+			require.NotContains(t, out, "onerror")
+			require.NotContains(t, out, "onclick")
+			require.NotContains(t, out, "javascript:")
+			require.NotContains(t, out, "style=")
+		})
+	}
+}
@@ -0,0 +1,197 @@
+// Package searchquery parses the search query language:
+//
+//	tag:go user:42 is:unanswered answers:any created:>2026-01-01 "exact phrase" -exclude
+//
+// A query is a list of clauses separated by whitespace, all of which must
+// hold. A clause is a word, a quoted phrase, either of them negated by a
+// leading '-', or a field:value filter for one of Fields. Other words with
+// a colon, such as URLs, are plain words. Only created takes a comparison
+// operator in front of its value. Parse checks the syntax; what a value
+// means is up to the caller, which reports bad values with NewError at the
+// clause's column.
+package searchquery
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Fields lists the filters the language knows.
+var Fields = []string{"tag", "user", "is", "answers", "created"}
+
+// Op is the comparison of a field filter.
+type Op string
+
+const (
+	OpEq Op = ""
+	OpGt Op = ">"
+	OpGe Op = ">="
+	OpLt Op = "<"
+	OpLe Op = "<="
+)
+
+// Clause is a node of a parsed query: *Term or *Field.
+type Clause interface {
+	// Column is the 1-based column, in runes, the clause starts at.
+	Column() int
+}
+
+// Term is a word or a quoted phrase to look for, or to exclude when
+// Negated.
+type Term struct {
+	Col     int
+	Text    string
+	Phrase  bool
+	Negated bool
+}
+
+func (t *Term) Column() int { return t.Col }
+
+// Field is a name:value filter, e.g. tag:go or created:>2026-01-01.
+type Field struct {
+	Col   int
+	Name  string
+	Op    Op
+	Value string
+	// ValueCol is the column the value starts at.
+	ValueCol int
+}
+
+func (f *Field) Column() int { return f.Col }
+
+// Query is a parsed query, clauses in the order written.
+type Query struct {
+	Clauses []Clause
+}
+
+// Error is a syntax or value error at a column of the query.
+type Error struct {
+	Col int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Col, e.Msg)
+}
+
+// NewError returns an Error at col.
+func NewError(col int, format string, args ...any) *Error {
+	return &Error{Col: col, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Parse parses s. Syntax errors are returned as *Error.
+func Parse(s string) (*Query, error) {
+	p := parser{src: []rune(s)}
+	return p.parse()
+}
+
+type parser struct {
+	src []rune
+	pos int
+}
+
+func (p *parser) parse() (*Query, error) {
+	q := &Query{}
+
+	for {
+		for p.pos < len(p.src) && unicode.IsSpace(p.src[p.pos]) {
+			p.pos++
+		}
+		if p.pos == len(p.src) {
+			return q, nil
+		}
+
+		c, err := p.clause()
+		if err != nil {
+			return nil, err
+		}
+		q.Clauses = append(q.Clauses, c)
+	}
+}
+
+func (p *parser) clause() (Clause, error) {
+	start := p.pos
+
+	negated := p.src[p.pos] == '-'
+	if negated {
+		p.pos++
+		if p.pos == len(p.src) || unicode.IsSpace(p.src[p.pos]) {
+			return nil, NewError(start+1, "'-' must be followed by a word or a phrase")
+		}
+	}
+
+	if p.src[p.pos] == '"' {
+		text, err := p.phrase()
+		if err != nil {
+			return nil, err
+		}
+		return &Term{Col: start + 1, Text: text, Phrase: true, Negated: negated}, nil
+	}
+
+	wordStart := p.pos
+	for p.pos < len(p.src) && !unicode.IsSpace(p.src[p.pos]) {
+		if p.src[p.pos] == '"' {
+			return nil, NewError(p.pos+1, "unexpected '\"' inside a word")
+		}
+		p.pos++
+	}
+	word := string(p.src[wordStart:p.pos])
+
+	name, value, ok := strings.Cut(word, ":")
+	name = strings.ToLower(name)
+	if !ok || !slices.Contains(Fields, name) {
+		return &Term{Col: start + 1, Text: word, Negated: negated}, nil
+	}
+
+	if negated {
+		return nil, NewError(start+1, "field filters cannot be negated")
+	}
+	return p.field(start, name, value)
+}
+
+// phrase reads a quoted phrase starting at the opening quote.
+func (p *parser) phrase() (string, error) {
+	open := p.pos
+	p.pos++
+
+	end := p.pos
+	for end < len(p.src) && p.src[end] != '"' {
+		end++
+	}
+	if end == len(p.src) {
+		return "", NewError(open+1, "unterminated phrase")
+	}
+
+	text := strings.Join(strings.Fields(string(p.src[p.pos:end])), " ")
+	if text == "" {
+		return "", NewError(open+1, "empty phrase")
+	}
+
+	p.pos = end + 1
+	return text, nil
+}
+
+func (p *parser) field(start int, name, value string) (Clause, error) {
+	f := &Field{Col: start + 1, Name: name, ValueCol: start + len([]rune(name)) + 2}
+
+	for _, op := range []Op{OpGe, OpLe, OpGt, OpLt} {
+		if strings.HasPrefix(value, string(op)) {
+			if name != "created" {
+				return nil, NewError(f.ValueCol, "%s does not support %q", name, op)
+			}
+			f.Op = op
+			value = value[len(op):]
+			f.ValueCol += len(op)
+			break
+		}
+	}
+
+	if value == "" {
+		return nil, NewError(f.ValueCol, "missing value for %s", name)
+	}
+	f.Value = value
+
+	return f, nil
+}
@@ -0,0 +1,65 @@
+package searchquery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	q, err := Parse(`tag:go user:42  is:unanswered created:>=2026-01-01 "exact  phrase" -exclude -"not this" pgx`)
+	require.NoError(t, err)
+	require.Equal(t, []Clause{
+		&Field{Col: 1, Name: "tag", Value: "go", ValueCol: 5},
+		&Field{Col: 8, Name: "user", Value: "42", ValueCol: 13},
+		&Field{Col: 17, Name: "is", Value: "unanswered", ValueCol: 20},
+		&Field{Col: 31, Name: "created", Op: OpGe, Value: "2026-01-01", ValueCol: 41},
+		&Term{Col: 52, Text: "exact phrase", Phrase: true},
+		&Term{Col: 68, Text: "exclude", Negated: true},
+		&Term{Col: 77, Text: "not this", Phrase: true, Negated: true},
+		&Term{Col: 89, Text: "pgx"},
+	}, q.Clauses)
+
+	q, err = Parse("   ")
+	require.NoError(t, err)
+	require.Empty(t, q.Clauses)
+
+	// Words that only look like fields stay words.
+	q, err = Parse("c++:templates 10:30 http://example.com/a?b=c error: -author:42 Tag:Go")
+	require.NoError(t, err)
+	require.Equal(t, []Clause{
+		&Term{Col: 1, Text: "c++:templates"},
+		&Term{Col: 15, Text: "10:30"},
+		&Term{Col: 21, Text: "http://example.com/a?b=c"},
+		&Term{Col: 46, Text: "error:"},
+		&Term{Col: 53, Text: "author:42", Negated: true},
+		&Field{Col: 64, Name: "tag", Value: "Go", ValueCol: 68},
+	}, q.Clauses)
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []struct {
+		query string
+		col   int
+		msg   string
+	}{
+		{`pgx "pool size`, 5, "unterminated phrase"},
+		{`pgx ""`, 5, "empty phrase"},
+		{`pgx - pool`, 5, "'-' must be followed by a word or a phrase"},
+		{`pool ab"c`, 8, `unexpected '"' inside a word`},
+		{`pgx tag:`, 9, "missing value for tag"},
+		{`-tag:go`, 1, "field filters cannot be negated"},
+		{`tag:>go`, 5, `tag does not support ">"`},
+		{`created:<=`, 11, "missing value for created"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.query, func(t *testing.T) {
+			_, err := Parse(tc.query)
+			var perr *Error
+			require.ErrorAs(t, err, &perr)
+			require.Equal(t, tc.col, perr.Col)
+			require.Equal(t, tc.msg, perr.Msg)
+		})
+	}
+}
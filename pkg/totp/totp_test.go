@@ -0,0 +1,65 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// RFC 6238 appendix B, SHA1 test vectors truncated to 8 digits.
+func TestHOTPVectors(t *testing.T) {
+	key := []byte("12345678901234567890")
+
+	cases := []struct {
+		unix int64
+		want string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+
+	for _, tc := range cases {
+		step := Step(time.Unix(tc.unix, 0))
+		require.Equal(t, tc.want, hotp(key, uint64(step), 8), "t=%d", tc.unix)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	require.Len(t, secret, 32)
+
+	now := time.Unix(1_700_000_000, 0)
+
+	code, err := Code(secret, Step(now))
+	require.NoError(t, err)
+
+	step, ok := Verify(secret, code, now, 1)
+	require.True(t, ok)
+	require.Equal(t, Step(now), step)
+
+	_, ok = Verify(secret, code, now.Add(Period), 1)
+	require.True(t, ok, "one step of drift is tolerated")
+
+	_, ok = Verify(secret, code, now.Add(3*Period), 1)
+	require.False(t, ok)
+
+	_, ok = Verify(secret, "12345", now, 1)
+	require.False(t, ok)
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("question-answer", "gopher", "JBSWY3DPEHPK3PXP")
+
+	require.True(t, strings.HasPrefix(uri, "otpauth://totp/question-answer:gopher?"))
+	require.Contains(t, uri, "secret=JBSWY3DPEHPK3PXP")
+	require.Contains(t, uri, "issuer=question-answer")
+	require.Contains(t, uri, "digits=6")
+	require.Contains(t, uri, "period=30")
+}
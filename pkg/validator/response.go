@@ -45,6 +45,10 @@ func ValidationError(errs validator.ValidationErrors) ValidationResponse {
 			message = "Допустимы только латинские буквы и цифры"
 		case "min":
 			message = fmt.Sprintf("Минимум %s символов", param)
+		case "max":
+			message = fmt.Sprintf("Максимум %s символов", param)
+		case "email":
+			message = "Введите корректный email"
 		case "oneof":
 			message = fmt.Sprintf("Ввидите валидное значение: %s", param)
 		}